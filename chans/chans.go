@@ -0,0 +1,94 @@
+// Package chans provides iterators bridging channels and [iter.Seq].
+package chans
+
+import (
+	"context"
+	"iter"
+	"reflect"
+)
+
+// FromChan returns an iterator that yields the values received from ch until
+// ch is closed.
+func FromChan[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromChanCtx returns an iterator that yields the values received from ch
+// until ch is closed or ctx is done.
+//
+// The loop and wait can be interrupted immediately by canceling the context.
+func FromChanCtx[T any](ctx context.Context, ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || !yield(v) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ToChan ranges the sequence on a new goroutine and sends its values to the
+// returned channel, which buffers up to buf values. The channel is closed when
+// the sequence ends or ctx is done. A negative buf is considered to be 0.
+//
+// The goroutine stops ranging the sequence at its next value after ctx is
+// done, so cancel ctx when the channel is no longer received from.
+// A panic in the sequence crashes the program, as with any goroutine.
+func ToChan[T any](ctx context.Context, iterator iter.Seq[T], buf int) <-chan T {
+	ch := make(chan T, max(buf, 0))
+	go func() {
+		defer close(ch)
+		for v := range iterator {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Merge returns an iterator that yields the values received from any of the
+// channels, in the order they are received, like a select statement over all
+// of them in a loop. A closed channel is removed from the selection, and the
+// iterator ends when all the channels are closed or ctx is done.
+//
+// When several channels are ready, one of them is chosen at random, as with a
+// select statement. Merge receives on the calling goroutine; it does not
+// start any goroutine.
+func Merge[T any](ctx context.Context, chs ...<-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		cases := make([]reflect.SelectCase, 0, len(chs)+1)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+		for _, ch := range chs {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+		}
+		for len(cases) > 1 {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 {
+				return
+			}
+			if !ok {
+				cases = append(cases[:chosen], cases[chosen+1:]...)
+				continue
+			}
+			var t T // v may hold a nil interface, as with chan error
+			reflect.ValueOf(&t).Elem().Set(v)
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package chans_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/chans"
+	"github.com/goaux/iter/transform"
+)
+
+func Example() {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := range 5 {
+			ch <- i
+		}
+	}()
+	odd := transform.Select(chans.FromChan(ch), func(v int) bool { return v%2 == 1 })
+	for v := range odd {
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 3
+}
+
+func values[T any](vs ...T) <-chan T {
+	ch := make(chan T, len(vs))
+	for _, v := range vs {
+		ch <- v
+	}
+	close(ch)
+	return ch
+}
+
+func TestFromChan(t *testing.T) {
+	got := slices.Collect(chans.FromChan(values(1, 2, 3)))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestFromChanCtx(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		got := slices.Collect(chans.FromChanCtx(context.TODO(), values(1, 2, 3)))
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+		i := 0
+		for range chans.FromChanCtx(ctx, make(chan int)) {
+			i++
+		}
+		if i != 0 {
+			t.Errorf("i must be 0, but %d", i)
+		}
+	})
+}
+
+func TestToChan(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		ch := chans.ToChan(context.TODO(), slices.Values([]int{1, 2, 3}), 1)
+		var got []int
+		for v := range ch {
+			got = append(got, v)
+		}
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		stopped := make(chan struct{})
+		source := func(yield func(int) bool) {
+			defer close(stopped)
+			for i := 0; yield(i); i++ {
+			}
+		}
+		ch := chans.ToChan(ctx, source, 0)
+		<-ch
+		cancel()
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("the source must be stopped")
+		}
+		for range ch {
+			// ch must be closed
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		got := slices.Sorted(chans.Merge(context.TODO(), values(1, 4), values[int](), values(2, 3, 5)))
+		if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("nil interface", func(t *testing.T) {
+		errTest := errors.New("test")
+		got := slices.Collect(chans.Merge(context.TODO(), values[error](nil, errTest)))
+		if !slices.Equal(got, []error{nil, errTest}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("no channels", func(t *testing.T) {
+		if got := slices.Collect(chans.Merge[int](context.TODO())); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		n := 0
+		for range chans.Merge(context.TODO(), values(1, 2), values(3)) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("n must be 1, but %d", n)
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+		ch := make(chan int)
+		got := slices.Collect(chans.Merge(ctx, values(1), ch))
+		if !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
@@ -0,0 +1,271 @@
+// Package combinatorics provides iterators over Cartesian products,
+// permutations, combinations and power sets, such as the cases of a
+// table-driven test matrix.
+//
+// The iterators yielding slices reuse a single slice, which is overwritten by
+// the next iteration, so that enumerating a large space does not allocate for
+// each element. Use [Clone] to get slices that may be retained.
+package combinatorics
+
+import (
+	"iter"
+	"slices"
+)
+
+// Clone returns an iterator that yields a copy of each slice of the sequence.
+// It makes the slices yielded by the other functions of this package safe to
+// retain, such as with [slices.Collect].
+func Clone[T any](iterator iter.Seq[[]T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		for v := range iterator {
+			if !yield(slices.Clone(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Product returns an iterator over the Cartesian product of two sequences:
+// each element of lhs paired with each element of rhs, in order.
+//
+// Both sequences are ranged at most once for each ranging of the result, so
+// they may be sequences that can be ranged only once. The elements of rhs are
+// cached in memory as they are first pulled. If rhs is empty, lhs is not
+// ranged past its first element, so lhs may be endless.
+func Product[S, T any](lhs iter.Seq[S], rhs iter.Seq[T]) iter.Seq2[S, T] {
+	return func(yield func(S, T) bool) {
+		var cache []T
+		first := true
+		for s := range lhs {
+			if first {
+				first = false
+				for t := range rhs {
+					cache = append(cache, t)
+					if !yield(s, t) {
+						return
+					}
+				}
+				if len(cache) == 0 {
+					return // the product is empty, whatever follows in lhs
+				}
+				continue
+			}
+			for _, t := range cache {
+				if !yield(s, t) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ProductN returns an iterator over the Cartesian product of the sequences.
+// Each yielded slice holds one element of each sequence, in the order of the
+// arguments, and the last sequence varies fastest. With no sequences, it
+// yields a single empty slice.
+//
+// Each sequence is ranged at most once for each ranging of the result, and
+// all but the first are cached in memory as with [Product]. If one of them is
+// empty, the iterator ends as soon as that is found.
+// The yielded slice is reused; see [Clone].
+func ProductN[T any](iterators ...iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(iterators)
+		buf := make([]T, n)
+		caches := make([][]T, n)
+		cached := make([]bool, n)
+		var product func(i int) bool
+		product = func(i int) bool {
+			if i == n {
+				return yield(buf)
+			}
+			if cached[i] {
+				for _, v := range caches[i] {
+					buf[i] = v
+					if !product(i + 1) {
+						return false
+					}
+				}
+				return true
+			}
+			for v := range iterators[i] {
+				if i > 0 {
+					caches[i] = append(caches[i], v)
+				}
+				buf[i] = v
+				if !product(i + 1) {
+					return false
+				}
+			}
+			cached[i] = true
+			// If a sequence other than the first is empty, the product is empty;
+			// stop rather than range the sequences before it to the end.
+			return i == 0 || len(caches[i]) > 0
+		}
+		product(0)
+	}
+}
+
+// Permutations returns an iterator over the permutations of k elements of s,
+// in the order of Python's itertools.permutations: lexicographic by the
+// positions of the elements in s. Elements are distinguished by position, not
+// by value. If k is greater than len(s), it yields nothing; if k is 0, it
+// yields a single empty slice.
+//
+// The yielded slice is reused; see [Clone].
+// Permutations panics if k is negative.
+func Permutations[T any](s []T, k int) iter.Seq[[]T] {
+	if k < 0 {
+		panic("cannot be less than 0")
+	}
+	return func(yield func([]T) bool) {
+		n := len(s)
+		if k > n {
+			return
+		}
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		cycles := make([]int, k)
+		for i := range cycles {
+			cycles[i] = n - i
+		}
+		buf := make([]T, k)
+		emit := func() bool {
+			for i, j := range indices[:k] {
+				buf[i] = s[j]
+			}
+			return yield(buf)
+		}
+		if !emit() {
+			return
+		}
+	next:
+		for {
+			for i := k - 1; i >= 0; i-- {
+				cycles[i]--
+				if cycles[i] == 0 {
+					// Rotate indices[i:] left by one, restoring their order.
+					v := indices[i]
+					copy(indices[i:], indices[i+1:])
+					indices[n-1] = v
+					cycles[i] = n - i
+					continue
+				}
+				j := n - cycles[i]
+				indices[i], indices[j] = indices[j], indices[i]
+				if !emit() {
+					return
+				}
+				continue next
+			}
+			return
+		}
+	}
+}
+
+// Combinations returns an iterator over the combinations of k elements of s,
+// in the order of Python's itertools.combinations: the elements of each
+// combination keep their order in s, and the combinations are lexicographic
+// by position. If k is greater than len(s), it yields nothing; if k is 0, it
+// yields a single empty slice.
+//
+// The yielded slice is reused; see [Clone].
+// Combinations panics if k is negative.
+func Combinations[T any](s []T, k int) iter.Seq[[]T] {
+	if k < 0 {
+		panic("cannot be less than 0")
+	}
+	return func(yield func([]T) bool) {
+		combinations(s, k, make([]T, k), yield)
+	}
+}
+
+// combinations yields the combinations of k elements of s in buf, which must
+// have length k, and reports whether yield always returned true.
+func combinations[T any](s []T, k int, buf []T, yield func([]T) bool) bool {
+	n := len(s)
+	if k > n {
+		return true
+	}
+	indices := make([]int, k)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		for i, j := range indices {
+			buf[i] = s[j]
+		}
+		if !yield(buf) {
+			return false
+		}
+		i := k - 1
+		for i >= 0 && indices[i] == i+n-k {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		indices[i]++
+		for j := i + 1; j < k; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
+// CombinationsWithReplacement is like [Combinations], but each element of s
+// may be chosen more than once, as in Python's
+// itertools.combinations_with_replacement. If s is empty and k is positive,
+// it yields nothing.
+//
+// The yielded slice is reused; see [Clone].
+// CombinationsWithReplacement panics if k is negative.
+func CombinationsWithReplacement[T any](s []T, k int) iter.Seq[[]T] {
+	if k < 0 {
+		panic("cannot be less than 0")
+	}
+	return func(yield func([]T) bool) {
+		n := len(s)
+		if n == 0 && k > 0 {
+			return
+		}
+		indices := make([]int, k)
+		buf := make([]T, k)
+		for {
+			for i, j := range indices {
+				buf[i] = s[j]
+			}
+			if !yield(buf) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			v := indices[i] + 1
+			for j := i; j < k; j++ {
+				indices[j] = v
+			}
+		}
+	}
+}
+
+// PowerSet returns an iterator over all the subsets of s, from the empty set
+// to s itself. The subsets are ordered by size, and then as by
+// [Combinations]; the elements of each subset keep their order in s.
+//
+// The yielded slice is reused; see [Clone].
+func PowerSet[T any](s []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		buf := make([]T, len(s))
+		for k := range len(s) + 1 {
+			if !combinations(s, k, buf[:k], yield) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package combinatorics_test
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/goaux/iter/combinatorics"
+)
+
+func ExampleProduct() {
+	for os, arch := range combinatorics.Product(
+		slices.Values([]string{"linux", "darwin"}),
+		slices.Values([]string{"amd64", "arm64"}),
+	) {
+		fmt.Println(os, arch)
+	}
+	// Output:
+	// linux amd64
+	// linux arm64
+	// darwin amd64
+	// darwin arm64
+}
+
+func ExamplePermutations() {
+	for p := range combinatorics.Permutations([]string{"a", "b", "c"}, 2) {
+		fmt.Println(p)
+	}
+	// Output:
+	// [a b]
+	// [a c]
+	// [b a]
+	// [b c]
+	// [c a]
+	// [c b]
+}
+
+// collect returns copies of the slices yielded by the sequence, formatted.
+func collect[T any](iterator iter.Seq[[]T]) []string {
+	got := []string{}
+	for v := range combinatorics.Clone(iterator) {
+		got = append(got, fmt.Sprint(v))
+	}
+	return got
+}
+
+func TestClone(t *testing.T) {
+	got := slices.Collect(combinatorics.Clone(combinatorics.Combinations([]int{1, 2, 3}, 2)))
+	if fmt.Sprint(got) != "[[1 2] [1 3] [2 3]]" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	t.Run("rhs ranged once", func(t *testing.T) {
+		ranged := 0
+		rhs := func(yield func(int) bool) {
+			ranged++
+			for _, v := range []int{1, 2} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		var got []string
+		for s, v := range combinatorics.Product(slices.Values([]string{"a", "b", "c"}), rhs) {
+			got = append(got, fmt.Sprint(s, v))
+		}
+		if !slices.Equal(got, []string{"a1", "a2", "b1", "b2", "c1", "c2"}) {
+			t.Errorf("got %v", got)
+		}
+		if ranged != 1 {
+			t.Errorf("rhs must be ranged once, but %d", ranged)
+		}
+	})
+
+	t.Run("empty lhs", func(t *testing.T) {
+		ranged := false
+		rhs := func(yield func(int) bool) { ranged = true }
+		for range combinatorics.Product(slices.Values([]string{}), rhs) {
+			t.Error("must not yield")
+		}
+		if ranged {
+			t.Error("rhs must not be ranged")
+		}
+	})
+
+	t.Run("empty rhs", func(t *testing.T) {
+		endless := func(yield func(int) bool) {
+			for i := 0; yield(i); i++ {
+			}
+		}
+		for range combinatorics.Product(endless, slices.Values([]int{})) {
+			t.Error("must not yield")
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		n := 0
+		for range combinatorics.Product(slices.Values([]int{1, 2}), slices.Values([]int{1, 2})) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		if n != 3 {
+			t.Errorf("got %d", n)
+		}
+	})
+}
+
+func TestProductN(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][]int
+		want []string
+	}{
+		{"none", nil, []string{"[]"}},
+		{"one", [][]int{{1, 2}}, []string{"[1]", "[2]"}},
+		{"three", [][]int{{1, 2}, {3}, {4, 5}}, []string{"[1 3 4]", "[1 3 5]", "[2 3 4]", "[2 3 5]"}},
+		{"empty", [][]int{{1, 2}, {}, {3}}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iterators []iter.Seq[int]
+			for _, s := range tt.in {
+				iterators = append(iterators, slices.Values(s))
+			}
+			got := collect(combinatorics.ProductN(iterators...))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductNEmpty(t *testing.T) {
+	endless := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+	ranged := 0
+	counted := func(yield func(int) bool) {
+		ranged++
+		for _, v := range []int{1, 2} {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+	for range combinatorics.ProductN(endless, counted, slices.Values([]int{})) {
+		t.Error("must not yield")
+	}
+	if ranged != 1 {
+		t.Errorf("counted must be ranged once, but %d", ranged)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	tests := []struct {
+		k    int
+		want []string
+	}{
+		{0, []string{"[]"}},
+		{1, []string{"[0]", "[1]", "[2]"}},
+		{3, []string{"[0 1 2]", "[0 2 1]", "[1 0 2]", "[1 2 0]", "[2 0 1]", "[2 1 0]"}},
+		{4, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.k), func(t *testing.T) {
+			got := collect(combinatorics.Permutations([]int{0, 1, 2}, tt.k))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("count", func(t *testing.T) {
+		n := 0
+		for range combinatorics.Permutations(make([]int, 5), 3) {
+			n++
+		}
+		if n != 60 {
+			t.Errorf("must be 60, but %d", n)
+		}
+	})
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		k    int
+		want []string
+	}{
+		{0, []string{"[]"}},
+		{2, []string{"[a b]", "[a c]", "[a d]", "[b c]", "[b d]", "[c d]"}},
+		{4, []string{"[a b c d]"}},
+		{5, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.k), func(t *testing.T) {
+			got := collect(combinatorics.Combinations([]string{"a", "b", "c", "d"}, tt.k))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("negative", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic")
+			}
+		}()
+		combinatorics.Combinations([]int{1}, -1)
+	})
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		k    int
+		want []string
+	}{
+		{"zero", []int{1, 2}, 0, []string{"[]"}},
+		{"two", []int{1, 2, 3}, 2, []string{"[1 1]", "[1 2]", "[1 3]", "[2 2]", "[2 3]", "[3 3]"}},
+		{"more than len", []int{1}, 3, []string{"[1 1 1]"}},
+		{"empty", []int{}, 1, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collect(combinatorics.CombinationsWithReplacement(tt.in, tt.k))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPowerSet(t *testing.T) {
+	got := collect(combinatorics.PowerSet([]int{1, 2, 3}))
+	want := []string{"[]", "[1]", "[2]", "[3]", "[1 2]", "[1 3]", "[2 3]", "[1 2 3]"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := collect(combinatorics.PowerSet([]int{})); !slices.Equal(got, []string{"[]"}) {
+		t.Errorf("got %v", got)
+	}
+}
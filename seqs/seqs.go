@@ -0,0 +1,121 @@
+// Package seqs provides functions generating sequences, such as numeric and
+// time ranges, to feed into the functions of the transform package.
+package seqs
+
+import (
+	"iter"
+	"time"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Range returns an iterator over the numbers from start up to but not
+// including stop, separated by step, like Python's range. If step is
+// negative, the numbers count down from start to above stop. If start is
+// already past stop, it yields nothing.
+//
+// The iterator ends instead of wrapping around when the next number would
+// overflow T. Floating-point numbers are computed as start + i*step, so that
+// rounding errors do not accumulate.
+//
+// Range panics if step is zero.
+func Range[T Number](start, stop, step T) iter.Seq[T] {
+	return numberRange(start, stop, step, false)
+}
+
+// RangeInclusive is like [Range], but includes stop if it is reached.
+func RangeInclusive[T Number](start, stop, step T) iter.Seq[T] {
+	return numberRange(start, stop, step, true)
+}
+
+func numberRange[T Number](start, stop, step T, inclusive bool) iter.Seq[T] {
+	if step == 0 {
+		panic("cannot be zero")
+	}
+	var half T = 1
+	half /= 2
+	float := half != 0
+	up := step > 0
+	within := func(v T) bool {
+		switch {
+		case up && inclusive:
+			return v <= stop
+		case up:
+			return v < stop
+		case inclusive:
+			return v >= stop
+		default:
+			return v > stop
+		}
+	}
+	return func(yield func(T) bool) {
+		v := start
+		for i := 1; within(v); i++ {
+			if !yield(v) {
+				return
+			}
+			next := v + step
+			if float {
+				next = start + T(i)*step
+			}
+			// The next number must move in the direction of step; otherwise it
+			// has wrapped around, or step is too small to change a float.
+			if up != (next > v) {
+				return
+			}
+			v = next
+		}
+	}
+}
+
+// Iterate returns an iterator that yields seed, f(seed), f(f(seed)), and so
+// on, forever. It is typically bounded by transform.Take or
+// transform.TakeWhile.
+func Iterate[T any](seed T, f func(T) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := seed; yield(v); v = f(v) {
+		}
+	}
+}
+
+// Unfold returns an iterator generating values from a state. f takes the
+// current state and returns the value to yield, the next state, and whether
+// to continue; the iterator ends without yielding when f returns false.
+func Unfold[S, T any](seed S, f func(S) (T, S, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		state := seed
+		for {
+			v, next, ok := f(state)
+			if !ok || !yield(v) {
+				return
+			}
+			state = next
+		}
+	}
+}
+
+// TimeRange returns an iterator over the times from start up to but not
+// including end, separated by step. If step is negative, the times count down
+// from start to after end.
+//
+// TimeRange panics if step is zero.
+func TimeRange(start, end time.Time, step time.Duration) iter.Seq[time.Time] {
+	if step == 0 {
+		panic("cannot be zero")
+	}
+	return func(yield func(time.Time) bool) {
+		for t := start; ; t = t.Add(step) {
+			if step > 0 && !t.Before(end) || step < 0 && !t.After(end) {
+				return
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package seqs_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/seqs"
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleRange() {
+	for i, v := range transform.ZipIndex(seqs.Range(10, 0, -3)) {
+		fmt.Println(i, v)
+	}
+	// Output:
+	// 0 10
+	// 1 7
+	// 2 4
+	// 3 1
+}
+
+func ExampleUnfold() {
+	fib := seqs.Unfold([2]int{0, 1}, func(s [2]int) (int, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, s[0] < 50
+	})
+	fmt.Println(slices.Collect(fib))
+	// Output:
+	// [0 1 1 2 3 5 8 13 21 34]
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		name              string
+		start, stop, step int
+		want              []int
+	}{
+		{"up", 0, 5, 1, []int{0, 1, 2, 3, 4}},
+		{"up by 2", 0, 5, 2, []int{0, 2, 4}},
+		{"down", 5, 0, -2, []int{5, 3, 1}},
+		{"empty", 5, 5, 1, []int{}},
+		{"wrong direction", 0, 5, -1, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.AppendSeq([]int{}, seqs.Range(tt.start, tt.stop, tt.step))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		got := slices.Collect(seqs.RangeInclusive[int8](120, math.MaxInt8, 5))
+		if !slices.Equal(got, []int8{120, 125}) {
+			t.Errorf("got %v", got)
+		}
+		got2 := slices.Collect(seqs.RangeInclusive[uint8](5, 0, math.MaxUint8))
+		if len(got2) != 0 {
+			t.Errorf("got %v", got2)
+		}
+		got3 := slices.Collect(seqs.RangeInclusive[uint8](250, math.MaxUint8, 1))
+		if len(got3) != 6 || got3[5] != math.MaxUint8 {
+			t.Errorf("got %v", got3)
+		}
+	})
+
+	t.Run("float", func(t *testing.T) {
+		got := slices.Collect(seqs.Range(0, 1, 0.1))
+		if len(got) != 10 {
+			t.Errorf("must yield 10 numbers, but %v", got)
+		}
+		if got[9] != 0.9 {
+			t.Errorf("last must be 0.9, but %v", got[9])
+		}
+	})
+
+	t.Run("float step too small", func(t *testing.T) {
+		got := slices.Collect(seqs.Range(1e20, 2e20, 1))
+		if len(got) != 1 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("zero step", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic")
+			}
+		}()
+		seqs.Range(0, 1, 0)
+	})
+}
+
+func TestRangeInclusive(t *testing.T) {
+	tests := []struct {
+		name              string
+		start, stop, step int
+		want              []int
+	}{
+		{"up", 0, 4, 2, []int{0, 2, 4}},
+		{"not reached", 0, 5, 2, []int{0, 2, 4}},
+		{"down", 3, 1, -1, []int{3, 2, 1}},
+		{"single", 5, 5, 1, []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.AppendSeq([]int{}, seqs.RangeInclusive(tt.start, tt.stop, tt.step))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("float", func(t *testing.T) {
+		got := slices.Collect(seqs.RangeInclusive(0, 1, 0.25))
+		if !slices.Equal(got, []float64{0, 0.25, 0.5, 0.75, 1}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestIterate(t *testing.T) {
+	got := slices.Collect(transform.Take(seqs.Iterate(1, func(v int) int { return v * 2 }), 5))
+	if !slices.Equal(got, []int{1, 2, 4, 8, 16}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestUnfold(t *testing.T) {
+	t.Run("stop immediately", func(t *testing.T) {
+		got := slices.Collect(seqs.Unfold(0, func(s int) (string, int, bool) { return "x", s, false }))
+		if len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var got []int
+		for v := range seqs.Unfold(0, func(s int) (int, int, bool) { return s, s + 1, true }) {
+			if v == 3 {
+				break
+			}
+			got = append(got, v)
+		}
+		if !slices.Equal(got, []int{0, 1, 2}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	t.Run("up", func(t *testing.T) {
+		got := slices.Collect(seqs.TimeRange(start, end, time.Hour))
+		want := []time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour)}
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("down", func(t *testing.T) {
+		got := slices.Collect(seqs.TimeRange(end, start, -2*time.Hour))
+		want := []time.Time{end, end.Add(-2 * time.Hour)}
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if got := slices.Collect(seqs.TimeRange(end, start, time.Hour)); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("zero step", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic")
+			}
+		}()
+		seqs.TimeRange(start, end, 0)
+	})
+}
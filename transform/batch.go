@@ -0,0 +1,66 @@
+package transform
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// BatchTimeout returns an iterator that groups the elements of the sequence
+// into batches. A batch is yielded when it holds maxSize elements, or when
+// maxWait has elapsed since its first element arrived, whichever comes first.
+// When the sequence ends, the pending batch is yielded.
+//
+// A maxSize less than 1 means batches are not limited in size, and a maxWait
+// less than or equal to 0 means batches are not limited in time.
+//
+// The sequence is ranged on its own goroutine, as with [Prefetch], so that a
+// batch can be yielded while the source is blocked. The iterator ends without
+// yielding the pending batch when ctx is done. Each yielded slice is freshly
+// allocated, so it may be retained by the loop body.
+func BatchTimeout[T any](ctx context.Context, iterator iter.Seq[T], maxSize int, maxWait time.Duration) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		ch, stop := pump(ctx, iterator, 0)
+		defer stop()
+		timer := time.NewTimer(maxWait)
+		timer.Stop()
+		defer timer.Stop()
+		var timeout <-chan time.Time // not nil while the pending batch has a deadline
+		var batch []T
+		flush := func() bool {
+			b := batch
+			batch = nil
+			timer.Stop()
+			timeout = nil
+			return yield(b)
+		}
+		for {
+			select {
+			case v, ok := <-ch:
+				if ctx.Err() != nil {
+					return
+				}
+				if !ok {
+					if len(batch) > 0 {
+						flush()
+					}
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer.Reset(maxWait)
+					timeout = timer.C
+				}
+				if len(batch) == maxSize && !flush() {
+					return
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
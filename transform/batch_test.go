@@ -0,0 +1,85 @@
+package transform_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/transform"
+)
+
+// step is an element of a timed sequence, yielded after sleeping for delay.
+type step struct {
+	delay time.Duration
+	v     int
+}
+
+// timed yields the values of the steps, sleeping before each of them.
+func timed(steps ...step) func(func(int) bool) {
+	return func(yield func(int) bool) {
+		for _, s := range steps {
+			time.Sleep(s.delay)
+			if !yield(s.v) {
+				return
+			}
+		}
+	}
+}
+
+func TestBatchTimeout(t *testing.T) {
+	unit := 50 * time.Millisecond
+
+	t.Run("size", func(t *testing.T) {
+		got := slices.Collect(transform.BatchTimeout(context.TODO(), slices.Values([]int{1, 2, 3, 4, 5}), 2, time.Hour))
+		if !slices.EqualFunc(got, [][]int{{1, 2}, {3, 4}, {5}}, slices.Equal) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("time", func(t *testing.T) {
+		source := timed(step{0, 1}, step{0, 2}, step{2 * unit, 3}, step{0, 4})
+		got := slices.Collect(transform.BatchTimeout(context.TODO(), source, 10, unit))
+		if !slices.EqualFunc(got, [][]int{{1, 2}, {3, 4}}, slices.Equal) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("timeout while the source blocks", func(t *testing.T) {
+		source := timed(step{0, 1}, step{4 * unit, 2})
+		start := time.Now()
+		for b := range transform.BatchTimeout(context.TODO(), source, 10, unit) {
+			if !slices.Equal(b, []int{1}) {
+				t.Errorf("got %v", b)
+			}
+			if elapsed := time.Since(start); elapsed >= 3*unit {
+				t.Errorf("the first batch must be yielded after about %v, but %v", unit, elapsed)
+			}
+			break
+		}
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		got := slices.Collect(transform.BatchTimeout(context.TODO(), slices.Values([]int{1, 2, 3}), 0, 0))
+		if !slices.EqualFunc(got, [][]int{{1, 2, 3}}, slices.Equal) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got := slices.Collect(transform.BatchTimeout(context.TODO(), slices.Values([]int{}), 2, unit))
+		if len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), unit)
+		defer cancel()
+		source := timed(step{0, 1}, step{4 * unit, 2})
+		got := slices.Collect(transform.BatchTimeout(ctx, source, 10, time.Hour))
+		if len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
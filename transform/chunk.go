@@ -0,0 +1,206 @@
+package transform
+
+import "iter"
+
+// Chunk returns an iterator over consecutive sub-slices of up to n elements of
+// the sequence. All but the last sub-slice will have size n.
+//
+// Each yielded slice is freshly allocated, so it may be retained by the loop
+// body. Use [ChunkReuse] to avoid the allocation.
+//
+// Chunk panics if n is less than 1.
+func Chunk[T any](iterator iter.Seq[T], n int) iter.Seq[[]T] {
+	return chunk(iterator, n, false)
+}
+
+// ChunkReuse is like [Chunk], but the yielded slice is reused.
+// The slice is only valid until the loop body returns; it is overwritten by
+// the next chunk.
+func ChunkReuse[T any](iterator iter.Seq[T], n int) iter.Seq[[]T] {
+	return chunk(iterator, n, true)
+}
+
+// Chunk2 returns an iterator over consecutive sub-slices of up to n pairs of
+// the sequence. The keys and the values of the pairs are yielded as two
+// slices of the same length. All but the last sub-slices will have size n.
+//
+// Each yielded slice is freshly allocated, so it may be retained by the loop
+// body. Use [Chunk2Reuse] to avoid the allocation.
+//
+// Chunk2 panics if n is less than 1.
+func Chunk2[K, V any](iterator iter.Seq2[K, V], n int) iter.Seq2[[]K, []V] {
+	return chunk2(iterator, n, false)
+}
+
+// Chunk2Reuse is like [Chunk2], but the yielded slices are reused.
+// The slices are only valid until the loop body returns; they are overwritten
+// by the next chunk.
+func Chunk2Reuse[K, V any](iterator iter.Seq2[K, V], n int) iter.Seq2[[]K, []V] {
+	return chunk2(iterator, n, true)
+}
+
+// Window returns an iterator over sliding windows of size elements of the
+// sequence. The first window starts at the first element and each following
+// window starts step elements after the previous one. Only full windows are
+// yielded, so a sequence shorter than size yields nothing. If step is greater
+// than size, the elements between the windows are skipped.
+//
+// Each yielded slice is freshly allocated, so it may be retained by the loop
+// body. Use [WindowReuse] to avoid the allocation.
+//
+// Window panics if size or step is less than 1.
+func Window[T any](iterator iter.Seq[T], size, step int) iter.Seq[[]T] {
+	return window(iterator, size, step, false)
+}
+
+// WindowReuse is like [Window], but the yielded slice is reused.
+// The slice is only valid until the loop body returns; it is overwritten by
+// the next window.
+func WindowReuse[T any](iterator iter.Seq[T], size, step int) iter.Seq[[]T] {
+	return window(iterator, size, step, true)
+}
+
+// Window2 is like [Window], but for sequences of pairs.
+// The keys and the values of the pairs are yielded as two slices of the same
+// length.
+//
+// Each yielded slice is freshly allocated, so it may be retained by the loop
+// body. Use [Window2Reuse] to avoid the allocation.
+//
+// Window2 panics if size or step is less than 1.
+func Window2[K, V any](iterator iter.Seq2[K, V], size, step int) iter.Seq2[[]K, []V] {
+	return window2(iterator, size, step, false)
+}
+
+// Window2Reuse is like [Window2], but the yielded slices are reused.
+// The slices are only valid until the loop body returns; they are overwritten
+// by the next window.
+func Window2Reuse[K, V any](iterator iter.Seq2[K, V], size, step int) iter.Seq2[[]K, []V] {
+	return window2(iterator, size, step, true)
+}
+
+func chunk[T any](iterator iter.Seq[T], n int, reuse bool) iter.Seq[[]T] {
+	if n < 1 {
+		panic("cannot be less than 1")
+	}
+	return func(yield func([]T) bool) {
+		var buf []T
+		for v := range iterator {
+			if buf == nil {
+				buf = make([]T, 0, n)
+			}
+			buf = append(buf, v)
+			if len(buf) < n {
+				continue
+			}
+			if !yield(buf) {
+				return
+			}
+			if reuse {
+				buf = buf[:0]
+			} else {
+				buf = nil
+			}
+		}
+		if len(buf) > 0 {
+			yield(buf)
+		}
+	}
+}
+
+func chunk2[K, V any](iterator iter.Seq2[K, V], n int, reuse bool) iter.Seq2[[]K, []V] {
+	if n < 1 {
+		panic("cannot be less than 1")
+	}
+	return func(yield func([]K, []V) bool) {
+		var keys []K
+		var values []V
+		for k, v := range iterator {
+			if keys == nil {
+				keys, values = make([]K, 0, n), make([]V, 0, n)
+			}
+			keys, values = append(keys, k), append(values, v)
+			if len(keys) < n {
+				continue
+			}
+			if !yield(keys, values) {
+				return
+			}
+			if reuse {
+				keys, values = keys[:0], values[:0]
+			} else {
+				keys, values = nil, nil
+			}
+		}
+		if len(keys) > 0 {
+			yield(keys, values)
+		}
+	}
+}
+
+func window[T any](iterator iter.Seq[T], size, step int, reuse bool) iter.Seq[[]T] {
+	if size < 1 || step < 1 {
+		panic("cannot be less than 1")
+	}
+	return func(yield func([]T) bool) {
+		buf := make([]T, 0, size)
+		skip := 0
+		for v := range iterator {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			buf = append(buf, v)
+			if len(buf) < size {
+				continue
+			}
+			out := buf
+			if !reuse {
+				out = append(make([]T, 0, size), buf...)
+			}
+			if !yield(out) {
+				return
+			}
+			if step < size {
+				buf = append(buf[:0], buf[step:]...)
+			} else {
+				buf = buf[:0]
+				skip = step - size
+			}
+		}
+	}
+}
+
+func window2[K, V any](iterator iter.Seq2[K, V], size, step int, reuse bool) iter.Seq2[[]K, []V] {
+	if size < 1 || step < 1 {
+		panic("cannot be less than 1")
+	}
+	return func(yield func([]K, []V) bool) {
+		keys, values := make([]K, 0, size), make([]V, 0, size)
+		skip := 0
+		for k, v := range iterator {
+			if skip > 0 {
+				skip--
+				continue
+			}
+			keys, values = append(keys, k), append(values, v)
+			if len(keys) < size {
+				continue
+			}
+			outK, outV := keys, values
+			if !reuse {
+				outK = append(make([]K, 0, size), keys...)
+				outV = append(make([]V, 0, size), values...)
+			}
+			if !yield(outK, outV) {
+				return
+			}
+			if step < size {
+				keys, values = append(keys[:0], keys[step:]...), append(values[:0], values[step:]...)
+			} else {
+				keys, values = keys[:0], values[:0]
+				skip = step - size
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package transform_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleChunk() {
+	for c := range transform.Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		fmt.Println(c)
+	}
+	// Output:
+	// [1 2]
+	// [3 4]
+	// [5]
+}
+
+func ExampleWindow() {
+	for w := range transform.Window(slices.Values([]int{1, 2, 3, 4, 5}), 3, 1) {
+		fmt.Println(w)
+	}
+	// Output:
+	// [1 2 3]
+	// [2 3 4]
+	// [3 4 5]
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		n    int
+		want [][]int
+	}{
+		{"empty", []int{}, 2, nil},
+		{"n = 1", []int{1, 2, 3}, 1, [][]int{{1}, {2}, {3}}},
+		{"divisible", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"remainder", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"n > len", []int{1, 2, 3}, 5, [][]int{{1, 2, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(transform.Chunk(slices.Values(tt.in), tt.n))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("break", func(t *testing.T) {
+		var got [][]int
+		for c := range transform.Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+			got = append(got, c)
+			break
+		}
+		if !slices.EqualFunc(got, [][]int{{1, 2}}, slices.Equal) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("n < 1", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic")
+			}
+		}()
+		transform.Chunk(slices.Values([]int{1}), 0)
+	})
+}
+
+func TestChunkReuse(t *testing.T) {
+	var got [][]int
+	var ptr *int
+	for c := range transform.ChunkReuse(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		if ptr != nil && ptr != &c[0] {
+			t.Error("slice must be reused")
+		}
+		ptr = &c[0]
+		got = append(got, slices.Clone(c))
+	}
+	if !slices.EqualFunc(got, [][]int{{1, 2}, {3, 4}, {5}}, slices.Equal) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestChunk2(t *testing.T) {
+	var keys [][]int
+	var values [][]string
+	for k, v := range transform.Chunk2(slices.All([]string{"a", "b", "c"}), 2) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.EqualFunc(keys, [][]int{{0, 1}, {2}}, slices.Equal) {
+		t.Errorf("keys %v", keys)
+	}
+	if !slices.EqualFunc(values, [][]string{{"a", "b"}, {"c"}}, slices.Equal) {
+		t.Errorf("values %v", values)
+	}
+}
+
+func TestChunk2Reuse(t *testing.T) {
+	var values [][]string
+	for _, v := range transform.Chunk2Reuse(slices.All([]string{"a", "b", "c"}), 2) {
+		values = append(values, slices.Clone(v))
+	}
+	if !slices.EqualFunc(values, [][]string{{"a", "b"}, {"c"}}, slices.Equal) {
+		t.Errorf("values %v", values)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         []int
+		size, step int
+		want       [][]int
+	}{
+		{"empty", []int{}, 2, 1, nil},
+		{"len < size", []int{1, 2}, 3, 1, nil},
+		{"step = 1", []int{1, 2, 3, 4}, 2, 1, [][]int{{1, 2}, {2, 3}, {3, 4}}},
+		{"step < size", []int{1, 2, 3, 4, 5, 6}, 3, 2, [][]int{{1, 2, 3}, {3, 4, 5}}},
+		{"step = size", []int{1, 2, 3, 4, 5}, 2, 2, [][]int{{1, 2}, {3, 4}}},
+		{"step > size", []int{1, 2, 3, 4, 5, 6, 7}, 2, 3, [][]int{{1, 2}, {4, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(transform.Window(slices.Values(tt.in), tt.size, tt.step))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			var reused [][]int
+			for w := range transform.WindowReuse(slices.Values(tt.in), tt.size, tt.step) {
+				reused = append(reused, slices.Clone(w))
+			}
+			if !slices.EqualFunc(reused, tt.want, slices.Equal) {
+				t.Errorf("reuse: got %v, want %v", reused, tt.want)
+			}
+		})
+	}
+
+	t.Run("step < 1", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("must panic")
+			}
+		}()
+		transform.Window(slices.Values([]int{1}), 1, 0)
+	})
+}
+
+func TestWindow2(t *testing.T) {
+	var keys [][]int
+	var values [][]string
+	for k, v := range transform.Window2(slices.All([]string{"a", "b", "c"}), 2, 1) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.EqualFunc(keys, [][]int{{0, 1}, {1, 2}}, slices.Equal) {
+		t.Errorf("keys %v", keys)
+	}
+	if !slices.EqualFunc(values, [][]string{{"a", "b"}, {"b", "c"}}, slices.Equal) {
+		t.Errorf("values %v", values)
+	}
+
+	values = nil
+	for _, v := range transform.Window2Reuse(slices.All([]string{"a", "b", "c"}), 2, 1) {
+		values = append(values, slices.Clone(v))
+	}
+	if !slices.EqualFunc(values, [][]string{{"a", "b"}, {"b", "c"}}, slices.Equal) {
+		t.Errorf("reuse: values %v", values)
+	}
+}
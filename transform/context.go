@@ -0,0 +1,103 @@
+package transform
+
+import (
+	"context"
+	"iter"
+)
+
+// WithContext returns an iterator over the sequence that ends when ctx is
+// done. ctx is checked before ranging the sequence and before yielding each
+// element, so a long pipeline over a slow source stops between elements once
+// ctx is cancelled. It cannot interrupt a source that blocks while producing
+// an element; use [Prefetch] for such a source.
+//
+// The iterator ends silently, as do the other functions of this package
+// taking a context. To tell a cancellation from the end of the sequence, use
+// tryseq.WithContext, tryseq.MapCtx and tryseq.SelectCtx instead, which yield
+// [context.Cause] of ctx as an error.
+func WithContext[T any](ctx context.Context, iterator iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for v := range iterator {
+			if ctx.Err() != nil || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// WithContext2 is like [WithContext], but for [iter.Seq2].
+func WithContext2[K, V any](ctx context.Context, iterator iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if ctx.Err() != nil {
+			return
+		}
+		for k, v := range iterator {
+			if ctx.Err() != nil || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// SelectCtx is like [Select], but f receives ctx, and the iterator ends when
+// ctx is done, as with [WithContext].
+func SelectCtx[T any](ctx context.Context, iterator iter.Seq[T], f func(context.Context, T) bool) iter.Seq[T] {
+	return Select(WithContext(ctx, iterator), func(v T) bool { return f(ctx, v) })
+}
+
+// Select2Ctx is like [Select2], but f receives ctx, and the iterator ends when
+// ctx is done, as with [WithContext2].
+func Select2Ctx[K, V any](ctx context.Context, iterator iter.Seq2[K, V], f func(context.Context, K, V) bool) iter.Seq2[K, V] {
+	return Select2(WithContext2(ctx, iterator), func(k K, v V) bool { return f(ctx, k, v) })
+}
+
+// MapCtx is like [Map], but f receives ctx, and the iterator ends when ctx is
+// done, as with [WithContext].
+func MapCtx[S, T any](ctx context.Context, iterator iter.Seq[S], f func(context.Context, S) T) iter.Seq[T] {
+	return Map(WithContext(ctx, iterator), func(s S) T { return f(ctx, s) })
+}
+
+// Map2Ctx is like [Map2], but f receives ctx, and the iterator ends when ctx
+// is done, as with [WithContext2].
+func Map2Ctx[S, T, U, V any](ctx context.Context, iterator iter.Seq2[S, T], f func(context.Context, S, T) (U, V)) iter.Seq2[U, V] {
+	return Map2(WithContext2(ctx, iterator), func(s S, t T) (U, V) { return f(ctx, s, t) })
+}
+
+// MapInCtx is like [MapIn], but f receives ctx, and the iterator ends when ctx
+// is done, as with [WithContext2].
+func MapInCtx[S, T, U any](ctx context.Context, iterator iter.Seq2[S, T], f func(context.Context, S, T) U) iter.Seq[U] {
+	return MapIn(WithContext2(ctx, iterator), func(s S, t T) U { return f(ctx, s, t) })
+}
+
+// MapOutCtx is like [MapOut], but f receives ctx, and the iterator ends when
+// ctx is done, as with [WithContext].
+func MapOutCtx[S, T, U any](ctx context.Context, iterator iter.Seq[S], f func(context.Context, S) (T, U)) iter.Seq2[T, U] {
+	return MapOut(WithContext(ctx, iterator), func(s S) (T, U) { return f(ctx, s) })
+}
+
+// SelectMapCtx is like [SelectMap], but f receives ctx, and the iterator ends
+// when ctx is done, as with [WithContext].
+func SelectMapCtx[S, T any](ctx context.Context, iterator iter.Seq[S], f func(context.Context, S) (T, bool)) iter.Seq[T] {
+	return SelectMap(WithContext(ctx, iterator), func(s S) (T, bool) { return f(ctx, s) })
+}
+
+// SelectMap2Ctx is like [SelectMap2], but f receives ctx, and the iterator
+// ends when ctx is done, as with [WithContext2].
+func SelectMap2Ctx[S, T, U, V any](ctx context.Context, iterator iter.Seq2[S, T], f func(context.Context, S, T) (U, V, bool)) iter.Seq2[U, V] {
+	return SelectMap2(WithContext2(ctx, iterator), func(s S, t T) (U, V, bool) { return f(ctx, s, t) })
+}
+
+// SelectMapInCtx is like [SelectMapIn], but f receives ctx, and the iterator
+// ends when ctx is done, as with [WithContext2].
+func SelectMapInCtx[S, T, U any](ctx context.Context, iterator iter.Seq2[S, T], f func(context.Context, S, T) (U, bool)) iter.Seq[U] {
+	return SelectMapIn(WithContext2(ctx, iterator), func(s S, t T) (U, bool) { return f(ctx, s, t) })
+}
+
+// SelectMapOutCtx is like [SelectMapOut], but f receives ctx, and the iterator
+// ends when ctx is done, as with [WithContext].
+func SelectMapOutCtx[S, T, U any](ctx context.Context, iterator iter.Seq[S], f func(context.Context, S) (T, U, bool)) iter.Seq2[T, U] {
+	return SelectMapOut(WithContext(ctx, iterator), func(s S) (T, U, bool) { return f(ctx, s) })
+}
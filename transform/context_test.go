@@ -0,0 +1,177 @@
+package transform_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleWithContext() {
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	defer cancel(nil)
+	for v := range transform.WithContext(ctx, slices.Values([]int{1, 2, 3})) {
+		fmt.Println(v)
+		if v == 2 {
+			cancel(fmt.Errorf("stopped at %d", v))
+		}
+	}
+	fmt.Println(context.Cause(ctx))
+	// Output:
+	// 1
+	// 2
+	// stopped at 2
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		got := slices.Collect(transform.WithContext(context.TODO(), slices.Values([]int{1, 2, 3})))
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("done before start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		ranged := false
+		source := func(yield func(int) bool) { ranged = true }
+		for range transform.WithContext(ctx, source) {
+		}
+		if ranged {
+			t.Error("source must not be ranged")
+		}
+	})
+}
+
+func TestWithContext2(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	got := map[int]string{}
+	for k, v := range transform.WithContext2(ctx, slices.All([]string{"a", "b", "c"})) {
+		got[k] = v
+		if k == 1 {
+			cancel()
+		}
+	}
+	if !maps.Equal(got, map[int]string{0: "a", 1: "b"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+type ctxKey struct{}
+
+func TestCtxVariants(t *testing.T) {
+	ctx := context.WithValue(context.TODO(), ctxKey{}, "x")
+	suffix := func(ctx context.Context) string { return ctx.Value(ctxKey{}).(string) }
+	in := []string{"a", "bb", "c"}
+
+	t.Run("SelectCtx", func(t *testing.T) {
+		got := slices.Collect(transform.SelectCtx(ctx, slices.Values(in), func(ctx context.Context, s string) bool {
+			return len(s) == 1 && suffix(ctx) == "x"
+		}))
+		if !slices.Equal(got, []string{"a", "c"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("Select2Ctx", func(t *testing.T) {
+		got := maps.Collect(transform.Select2Ctx(ctx, slices.All(in), func(ctx context.Context, i int, s string) bool {
+			return i > 0 && suffix(ctx) == "x"
+		}))
+		if !maps.Equal(got, map[int]string{1: "bb", 2: "c"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("MapCtx", func(t *testing.T) {
+		got := slices.Collect(transform.MapCtx(ctx, slices.Values(in), func(ctx context.Context, s string) string {
+			return s + suffix(ctx)
+		}))
+		if !slices.Equal(got, []string{"ax", "bbx", "cx"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("Map2Ctx", func(t *testing.T) {
+		got := maps.Collect(transform.Map2Ctx(ctx, slices.All(in), func(ctx context.Context, i int, s string) (string, int) {
+			return s + suffix(ctx), i
+		}))
+		if !maps.Equal(got, map[string]int{"ax": 0, "bbx": 1, "cx": 2}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("MapInCtx", func(t *testing.T) {
+		got := slices.Collect(transform.MapInCtx(ctx, slices.All(in), func(ctx context.Context, i int, s string) string {
+			return strings.Repeat(s, i) + suffix(ctx)
+		}))
+		if !slices.Equal(got, []string{"x", "bbx", "ccx"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("MapOutCtx", func(t *testing.T) {
+		got := maps.Collect(transform.MapOutCtx(ctx, slices.Values(in), func(ctx context.Context, s string) (string, int) {
+			return s + suffix(ctx), len(s)
+		}))
+		if !maps.Equal(got, map[string]int{"ax": 1, "bbx": 2, "cx": 1}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("SelectMapCtx", func(t *testing.T) {
+		got := slices.Collect(transform.SelectMapCtx(ctx, slices.Values(in), func(ctx context.Context, s string) (string, bool) {
+			return s + suffix(ctx), len(s) == 1
+		}))
+		if !slices.Equal(got, []string{"ax", "cx"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("SelectMap2Ctx", func(t *testing.T) {
+		got := maps.Collect(transform.SelectMap2Ctx(ctx, slices.All(in), func(ctx context.Context, i int, s string) (string, int, bool) {
+			return s + suffix(ctx), i, i != 1
+		}))
+		if !maps.Equal(got, map[string]int{"ax": 0, "cx": 2}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("SelectMapInCtx", func(t *testing.T) {
+		got := slices.Collect(transform.SelectMapInCtx(ctx, slices.All(in), func(ctx context.Context, i int, s string) (string, bool) {
+			return s + suffix(ctx), i != 1
+		}))
+		if !slices.Equal(got, []string{"ax", "cx"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("SelectMapOutCtx", func(t *testing.T) {
+		got := maps.Collect(transform.SelectMapOutCtx(ctx, slices.Values(in), func(ctx context.Context, s string) (string, int, bool) {
+			return s + suffix(ctx), len(s), len(s) == 2
+		}))
+		if !maps.Equal(got, map[string]int{"bbx": 2}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var calls []string
+		for range transform.MapCtx(ctx, slices.Values(in), func(ctx context.Context, s string) string {
+			calls = append(calls, s)
+			cancel()
+			return s
+		}) {
+		}
+		if !slices.Equal(calls, []string{"a"}) {
+			t.Errorf("f must not be called after ctx is done, but %v", calls)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package transform
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// Debounce returns an iterator that coalesces bursts of elements: it yields
+// an element only once no other element has arrived for the quiet duration,
+// and drops the elements that were superseded within the burst. When the
+// sequence ends, the pending element is yielded immediately.
+//
+// For example, Debounce over signals.Wait turns several SIGHUPs sent in a row
+// into a single reload.
+//
+// The sequence is ranged on its own goroutine, as with [Prefetch].
+// The iterator ends without yielding the pending element when ctx is done.
+func Debounce[T any](ctx context.Context, iterator iter.Seq[T], quiet time.Duration) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ch, stop := pump(ctx, iterator, 0)
+		defer stop()
+		timer := time.NewTimer(quiet)
+		timer.Stop()
+		defer timer.Stop()
+		var pending T
+		var timeout <-chan time.Time // not nil while an element is pending
+		for {
+			select {
+			case v, ok := <-ch:
+				if ctx.Err() != nil {
+					return
+				}
+				if !ok {
+					if timeout != nil {
+						yield(pending)
+					}
+					return
+				}
+				pending = v
+				timer.Reset(quiet)
+				timeout = timer.C
+			case <-timeout:
+				timeout = nil
+				if !yield(pending) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Throttle returns an iterator that yields at most one element per interval
+// every. It yields an element immediately if at least every has elapsed since
+// the previous yielded element, and drops it otherwise; that is, it keeps the
+// leading element of each interval. Use [ThrottleTrailing] to keep the latest
+// element instead.
+//
+// The sequence is ranged on its own goroutine, as with [Prefetch].
+// The iterator ends when ctx is done.
+func Throttle[T any](ctx context.Context, iterator iter.Seq[T], every time.Duration) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ch, stop := pump(ctx, iterator, 0)
+		defer stop()
+		var last time.Time
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || ctx.Err() != nil {
+					return
+				}
+				if now := time.Now(); last.IsZero() || now.Sub(last) >= every {
+					last = now
+					if !yield(v) {
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ThrottleTrailing returns an iterator that yields at most one element per
+// interval every. An element arriving while no interval is running starts
+// one; at the end of the interval, the latest element that arrived during it
+// is yielded, and the others are dropped. When the sequence ends, the pending
+// element is yielded immediately. Use [Throttle] to keep the leading element
+// of each interval instead.
+//
+// The sequence is ranged on its own goroutine, as with [Prefetch].
+// The iterator ends without yielding the pending element when ctx is done.
+func ThrottleTrailing[T any](ctx context.Context, iterator iter.Seq[T], every time.Duration) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ch, stop := pump(ctx, iterator, 0)
+		defer stop()
+		timer := time.NewTimer(every)
+		timer.Stop()
+		defer timer.Stop()
+		var pending T
+		var timeout <-chan time.Time // not nil while an interval is running
+		for {
+			select {
+			case v, ok := <-ch:
+				if ctx.Err() != nil {
+					return
+				}
+				if !ok {
+					if timeout != nil {
+						yield(pending)
+					}
+					return
+				}
+				pending = v
+				if timeout == nil {
+					timer.Reset(every)
+					timeout = timer.C
+				}
+			case <-timeout:
+				timeout = nil
+				if !yield(pending) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
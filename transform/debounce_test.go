@@ -0,0 +1,74 @@
+package transform_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/transform"
+)
+
+func TestDebounce(t *testing.T) {
+	unit := 50 * time.Millisecond
+
+	t.Run("bursts", func(t *testing.T) {
+		source := timed(step{0, 1}, step{0, 2}, step{0, 3}, step{4 * unit, 4}, step{0, 5})
+		got := slices.Collect(transform.Debounce(context.TODO(), source, unit))
+		if !slices.Equal(got, []int{3, 5}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("quiet while the source blocks", func(t *testing.T) {
+		source := timed(step{0, 1}, step{6 * unit, 2})
+		start := time.Now()
+		for v := range transform.Debounce(context.TODO(), source, unit) {
+			if v != 1 {
+				t.Errorf("got %d", v)
+			}
+			if elapsed := time.Since(start); elapsed >= 4*unit {
+				t.Errorf("1 must be yielded after about %v, but %v", unit, elapsed)
+			}
+			break
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), unit)
+		defer cancel()
+		source := timed(step{0, 1}, step{3 * unit, 2})
+		got := slices.Collect(transform.Debounce(ctx, source, time.Hour))
+		if len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestThrottle(t *testing.T) {
+	unit := 50 * time.Millisecond
+	source := timed(step{0, 1}, step{0, 2}, step{0, 3}, step{3 * unit, 4}, step{0, 5})
+	got := slices.Collect(transform.Throttle(context.TODO(), source, 2*unit))
+	if !slices.Equal(got, []int{1, 4}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestThrottleTrailing(t *testing.T) {
+	unit := 50 * time.Millisecond
+
+	t.Run("intervals", func(t *testing.T) {
+		source := timed(step{0, 1}, step{0, 2}, step{0, 3}, step{4 * unit, 4}, step{0, 5}, step{4 * unit, 6})
+		got := slices.Collect(transform.ThrottleTrailing(context.TODO(), source, 2*unit))
+		if !slices.Equal(got, []int{3, 5, 6}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got := slices.Collect(transform.ThrottleTrailing(context.TODO(), slices.Values([]int{}), unit))
+		if len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package transform
+
+import (
+	"container/list"
+	"iter"
+)
+
+// Distinct returns an iterator over the elements of the sequence with
+// duplicates removed. The first occurrence of each element is yielded.
+//
+// Distinct remembers every element it has yielded, so its memory grows with
+// the number of distinct elements. See [DistinctByLRU] for a bounded variant.
+func Distinct[T comparable](iterator iter.Seq[T]) iter.Seq[T] {
+	return DistinctBy(iterator, func(v T) T { return v })
+}
+
+// Distinct2 returns an iterator over the pairs of the sequence with duplicate
+// keys removed. The first pair of each key is yielded.
+func Distinct2[K comparable, V any](iterator iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		seen := make(map[K]struct{})
+		for k, v := range iterator {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctBy returns an iterator over the elements of the sequence with
+// duplicates removed, where two elements are duplicates if key returns the
+// same value for them. The first element of each key is yielded.
+func DistinctBy[T any, K comparable](iterator iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for v := range iterator {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DistinctByLRU is like [DistinctBy], but remembers at most capacity keys,
+// evicting the least recently seen key when full. An element is dropped only
+// if its key is among the remembered keys, so a duplicate may be yielded again
+// after its key has been evicted. This bounds the memory used for long or
+// infinite sequences.
+//
+// A capacity less than 1 is considered to be 1, which removes consecutive
+// duplicates only.
+func DistinctByLRU[T any, K comparable](iterator iter.Seq[T], key func(T) K, capacity int) iter.Seq[T] {
+	capacity = max(capacity, 1)
+	return func(yield func(T) bool) {
+		seen := make(map[K]*list.Element)
+		order := list.New() // front is the most recently seen key
+		for v := range iterator {
+			k := key(v)
+			if e, ok := seen[k]; ok {
+				order.MoveToFront(e)
+				continue
+			}
+			if order.Len() >= capacity {
+				delete(seen, order.Remove(order.Back()).(K))
+			}
+			seen[k] = order.PushFront(k)
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Compact returns an iterator that replaces consecutive runs of equal
+// elements with a single copy, like [slices.Compact] does for slices.
+func Compact[T comparable](iterator iter.Seq[T]) iter.Seq[T] {
+	return CompactFunc(iterator, func(a, b T) bool { return a == b })
+}
+
+// CompactFunc is like [Compact], but uses an equality function to compare
+// elements. For runs of elements that compare equal, the first one is yielded.
+func CompactFunc[T any](iterator iter.Seq[T], eq func(T, T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var last T
+		first := true
+		for v := range iterator {
+			if !first && eq(last, v) {
+				continue
+			}
+			first = false
+			last = v
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Compact2 returns an iterator that replaces consecutive runs of pairs with
+// equal keys with the first pair of the run.
+func Compact2[K comparable, V any](iterator iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var last K
+		first := true
+		for k, v := range iterator {
+			if !first && last == k {
+				continue
+			}
+			first = false
+			last = k
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package transform_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func TestDistinct(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"empty", []int{}, []int{}},
+		{"unique", []int{1, 2, 3}, []int{1, 2, 3}},
+		{"duplicates", []int{1, 2, 1, 3, 2, 1}, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Collect(transform.Distinct(slices.Values(tt.in)))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("got %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinct2(t *testing.T) {
+	var keys []string
+	var values []int
+	for k, v := range transform.Distinct2(transform.Zip(
+		slices.Values([]string{"a", "b", "a", "c"}),
+		slices.Values([]int{1, 2, 3, 4}),
+	)) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Errorf("keys %v", keys)
+	}
+	if !slices.Equal(values, []int{1, 2, 4}) {
+		t.Errorf("values %v", values)
+	}
+}
+
+func TestDistinctBy(t *testing.T) {
+	s := slices.Collect(transform.DistinctBy(
+		slices.Values([]string{"a", "B", "A", "b", "c"}),
+		strings.ToLower,
+	))
+	if !slices.Equal(s, []string{"a", "B", "c"}) {
+		t.Errorf("got %v", s)
+	}
+}
+
+func TestDistinctByLRU(t *testing.T) {
+	id := func(v int) int { return v }
+	tests := []struct {
+		name     string
+		in       []int
+		capacity int
+		want     []int
+	}{
+		{"within capacity", []int{1, 2, 1, 2, 3}, 3, []int{1, 2, 3}},
+		{"evicted", []int{1, 2, 3, 1}, 2, []int{1, 2, 3, 1}},
+		{"refreshed", []int{1, 2, 1, 3, 1, 2}, 2, []int{1, 2, 3, 2}},
+		{"capacity < 1", []int{1, 1, 2, 1}, 0, []int{1, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Collect(transform.DistinctByLRU(slices.Values(tt.in), id, tt.capacity))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("got %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"empty", []int{}, []int{}},
+		{"zero first", []int{0, 0, 1}, []int{0, 1}},
+		{"runs", []int{1, 1, 2, 2, 2, 1, 3, 3}, []int{1, 2, 1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Collect(transform.Compact(slices.Values(tt.in)))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("got %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompactFunc(t *testing.T) {
+	s := slices.Collect(transform.CompactFunc(
+		slices.Values([]string{"a", "A", "b", "B", "a"}),
+		strings.EqualFold,
+	))
+	if !slices.Equal(s, []string{"a", "b", "a"}) {
+		t.Errorf("got %v", s)
+	}
+}
+
+func TestCompact2(t *testing.T) {
+	var values []int
+	for _, v := range transform.Compact2(transform.Zip(
+		slices.Values([]string{"a", "a", "b", "a"}),
+		slices.Values([]int{1, 2, 3, 4}),
+	)) {
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{1, 3, 4}) {
+		t.Errorf("got %v", values)
+	}
+}
@@ -0,0 +1,75 @@
+package transform
+
+import "iter"
+
+// Flatten returns a single iterator concatenating the iterators yielded by
+// the sequence. It is the dynamic counterpart of [Concat].
+func Flatten[T any](iterators iter.Seq[iter.Seq[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for iterator := range iterators {
+			for v := range iterator {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Flatten2 returns a single iterator concatenating the iterators yielded by
+// the sequence. It is the dynamic counterpart of [Concat2].
+func Flatten2[K, V any](iterators iter.Seq[iter.Seq2[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for iterator := range iterators {
+			for k, v := range iterator {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlattenSlices returns an iterator over the elements of the slices yielded
+// by the sequence, in order.
+func FlattenSlices[T any](iterator iter.Seq[[]T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for s := range iterator {
+			for _, v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap transforms each element of [iter.Seq][S] to an [iter.Seq][T] using f,
+// and returns a single iterator concatenating the results.
+// f is called lazily, when the previous result has been exhausted.
+func FlatMap[S, T any](iterator iter.Seq[S], f func(S) iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for s := range iterator {
+			for t := range f(s) {
+				if !yield(t) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FlatMap2 transforms each pair of [iter.Seq2][S, T] to an [iter.Seq2][U, V]
+// using f, and returns a single iterator concatenating the results.
+// f is called lazily, when the previous result has been exhausted.
+func FlatMap2[S, T, U, V any](iterator iter.Seq2[S, T], f func(S, T) iter.Seq2[U, V]) iter.Seq2[U, V] {
+	return func(yield func(U, V) bool) {
+		for s, t := range iterator {
+			for u, v := range f(s, t) {
+				if !yield(u, v) {
+					return
+				}
+			}
+		}
+	}
+}
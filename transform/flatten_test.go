@@ -0,0 +1,107 @@
+package transform_test
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/bufioreader"
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleFlatMap() {
+	files := map[string]string{
+		"a.txt": "alpha\nbeta\n",
+		"b.txt": "gamma\n",
+	}
+	lines := transform.FlatMap(
+		slices.Values([]string{"a.txt", "b.txt"}),
+		func(name string) iter.Seq[string] {
+			r := bufioreader.NewReader(strings.NewReader(files[name]))
+			return transform.Values(r.ReadString('\n'))
+		},
+	)
+	for line := range lines {
+		fmt.Printf("%q\n", line)
+	}
+	// Output:
+	// "alpha\n"
+	// "beta\n"
+	// "gamma\n"
+}
+
+func TestFlatten(t *testing.T) {
+	t.Run("", func(t *testing.T) {
+		s := slices.Collect(transform.Flatten(slices.Values([]iter.Seq[int]{
+			slices.Values([]int{1, 2}),
+			slices.Values([]int{}),
+			slices.Values([]int{3}),
+		})))
+		if !slices.Equal(s, []int{1, 2, 3}) {
+			t.Errorf("got %v", s)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var s []int
+		for v := range transform.Flatten(slices.Values([]iter.Seq[int]{
+			slices.Values([]int{1, 2}),
+			slices.Values([]int{3}),
+		})) {
+			s = append(s, v)
+			if v == 2 {
+				break
+			}
+		}
+		if !slices.Equal(s, []int{1, 2}) {
+			t.Errorf("got %v", s)
+		}
+	})
+}
+
+func TestFlatten2(t *testing.T) {
+	m := maps.Collect(transform.Flatten2(slices.Values([]iter.Seq2[int, string]{
+		maps.All(map[int]string{1: "a", 2: "b"}),
+		maps.All(map[int]string{3: "c"}),
+	})))
+	want := map[int]string{1: "a", 2: "b", 3: "c"}
+	if !maps.Equal(m, want) {
+		t.Errorf("got %v", m)
+	}
+}
+
+func TestFlattenSlices(t *testing.T) {
+	s := slices.Collect(transform.FlattenSlices(slices.Values([][]int{{1, 2}, nil, {3}})))
+	if !slices.Equal(s, []int{1, 2, 3}) {
+		t.Errorf("got %v", s)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	s := slices.Collect(transform.FlatMap(
+		slices.Values([]int{1, 2, 3}),
+		func(n int) iter.Seq[int] { return transform.Take(slices.Values([]int{n, n, n}), n) },
+	))
+	if !slices.Equal(s, []int{1, 2, 2, 3, 3, 3}) {
+		t.Errorf("got %v", s)
+	}
+}
+
+func TestFlatMap2(t *testing.T) {
+	m := maps.Collect(transform.FlatMap2(
+		maps.All(map[string]string{"a": "x y", "b": "z"}),
+		func(k, v string) iter.Seq2[string, string] {
+			return transform.MapOut(
+				slices.Values(strings.Fields(v)),
+				func(f string) (string, string) { return k + f, f },
+			)
+		},
+	))
+	want := map[string]string{"ax": "x", "ay": "y", "bz": "z"}
+	if !maps.Equal(m, want) {
+		t.Errorf("got %v", m)
+	}
+}
@@ -0,0 +1,92 @@
+package transform
+
+import "iter"
+
+// Fold applies f to an accumulator and each element of the sequence in order,
+// starting with init, and returns the final accumulator.
+// If the sequence is empty, Fold returns init.
+func Fold[T, A any](iterator iter.Seq[T], init A, f func(A, T) A) A {
+	acc := init
+	for v := range iterator {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Fold2 applies f to an accumulator and each pair of the sequence in order,
+// starting with init, and returns the final accumulator.
+// If the sequence is empty, Fold2 returns init.
+func Fold2[K, V, A any](iterator iter.Seq2[K, V], init A, f func(A, K, V) A) A {
+	acc := init
+	for k, v := range iterator {
+		acc = f(acc, k, v)
+	}
+	return acc
+}
+
+// Reduce is like [Fold], but uses the first element of the sequence as the
+// initial accumulator. It returns the final accumulator and true, or the zero
+// value and false if the sequence is empty.
+func Reduce[T any](iterator iter.Seq[T], f func(T, T) T) (T, bool) {
+	var acc T
+	ok := false
+	for v := range iterator {
+		if ok {
+			acc = f(acc, v)
+		} else {
+			acc, ok = v, true
+		}
+	}
+	return acc, ok
+}
+
+// Reduce2 is like [Fold2], but uses the first pair of the sequence as the
+// initial accumulator. f receives the accumulated pair followed by the next
+// pair. It returns the final accumulated pair and true, or zero values and
+// false if the sequence is empty.
+func Reduce2[K, V any](iterator iter.Seq2[K, V], f func(K, V, K, V) (K, V)) (K, V, bool) {
+	var accK K
+	var accV V
+	ok := false
+	for k, v := range iterator {
+		if ok {
+			accK, accV = f(accK, accV, k, v)
+		} else {
+			accK, accV, ok = k, v, true
+		}
+	}
+	return accK, accV, ok
+}
+
+// Scan returns an iterator over the running accumulators of [Fold].
+// For each element of the sequence, it applies f to the accumulator and the
+// element, and yields the result. The initial accumulator init is not yielded,
+// so the returned sequence has the same length as the input.
+//
+// For example, Scan over 1, 2, 3 with init 0 and addition yields 1, 3, 6.
+func Scan[T, A any](iterator iter.Seq[T], init A, f func(A, T) A) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := init
+		for v := range iterator {
+			acc = f(acc, v)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// Scan2 returns an iterator over the running accumulators of [Fold2].
+// For each pair of the sequence, it applies f to the accumulator and the
+// pair, and yields the result. The initial accumulator init is not yielded.
+func Scan2[K, V, A any](iterator iter.Seq2[K, V], init A, f func(A, K, V) A) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := init
+		for k, v := range iterator {
+			acc = f(acc, k, v)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package transform_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleScan() {
+	sums := transform.Scan(slices.Values([]int{1, 2, 3, 4}), 0, func(acc, v int) int { return acc + v })
+	fmt.Println(slices.Collect(sums))
+	// Output:
+	// [1 3 6 10]
+}
+
+func TestFold(t *testing.T) {
+	t.Run("sum", func(t *testing.T) {
+		got := transform.Fold(slices.Values([]int{1, 2, 3}), 10, func(acc, v int) int { return acc + v })
+		if got != 16 {
+			t.Errorf("got %d", got)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got := transform.Fold(slices.Values([]int{}), 10, func(acc, v int) int { return acc + v })
+		if got != 10 {
+			t.Errorf("got %d", got)
+		}
+	})
+
+	t.Run("different types", func(t *testing.T) {
+		got := transform.Fold(slices.Values([]string{"a", "bb", "ccc"}), 0, func(acc int, s string) int { return acc + len(s) })
+		if got != 6 {
+			t.Errorf("got %d", got)
+		}
+	})
+}
+
+func TestFold2(t *testing.T) {
+	got := transform.Fold2(
+		slices.All([]string{"a", "b", "c"}),
+		"",
+		func(acc string, i int, s string) string { return acc + fmt.Sprint(i) + s },
+	)
+	if got != "0a1b2c" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	t.Run("max", func(t *testing.T) {
+		got, ok := transform.Reduce(slices.Values([]int{3, 7, 2}), func(a, b int) int { return max(a, b) })
+		if !ok || got != 7 {
+			t.Errorf("got %d, %v", got, ok)
+		}
+	})
+
+	t.Run("single", func(t *testing.T) {
+		got, ok := transform.Reduce(slices.Values([]int{3}), func(a, b int) int { panic("must not be called") })
+		if !ok || got != 3 {
+			t.Errorf("got %d, %v", got, ok)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got, ok := transform.Reduce(slices.Values([]int{}), func(a, b int) int { return max(a, b) })
+		if ok || got != 0 {
+			t.Errorf("got %d, %v", got, ok)
+		}
+	})
+}
+
+func TestReduce2(t *testing.T) {
+	t.Run("longest", func(t *testing.T) {
+		i, s, ok := transform.Reduce2(
+			slices.All([]string{"a", "ccc", "bb"}),
+			func(i int, s string, j int, u string) (int, string) {
+				if len(u) > len(s) {
+					return j, u
+				}
+				return i, s
+			},
+		)
+		if !ok || i != 1 || s != "ccc" {
+			t.Errorf("got %d, %q, %v", i, s, ok)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, _, ok := transform.Reduce2(
+			slices.All([]string{}),
+			func(i int, s string, j int, u string) (int, string) { return i, s },
+		)
+		if ok {
+			t.Error("must be false")
+		}
+	})
+}
+
+func TestScan(t *testing.T) {
+	t.Run("prefix sum", func(t *testing.T) {
+		s := slices.Collect(transform.Scan(slices.Values([]int{1, 2, 3}), 0, func(acc, v int) int { return acc + v }))
+		if !slices.Equal(s, []int{1, 3, 6}) {
+			t.Errorf("got %v", s)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		s := slices.Collect(transform.Scan(slices.Values([]int{}), 0, func(acc, v int) int { return acc + v }))
+		if len(s) != 0 {
+			t.Errorf("got %v", s)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var s []int
+		for v := range transform.Scan(slices.Values([]int{1, 2, 3}), 0, func(acc, v int) int { return acc + v }) {
+			s = append(s, v)
+			if v >= 3 {
+				break
+			}
+		}
+		if !slices.Equal(s, []int{1, 3}) {
+			t.Errorf("got %v", s)
+		}
+	})
+}
+
+func TestScan2(t *testing.T) {
+	s := slices.Collect(transform.Scan2(
+		slices.All([]string{"a", "b", "c"}),
+		"",
+		func(acc string, _ int, s string) string { return acc + strings.ToUpper(s) },
+	))
+	if !slices.Equal(s, []string{"A", "AB", "ABC"}) {
+		t.Errorf("got %v", s)
+	}
+}
@@ -0,0 +1,47 @@
+package transform
+
+import "iter"
+
+// ChunkBy returns an iterator that groups consecutive elements of the
+// sequence sharing the same key, like the Unix command uniq does for sorted
+// input. For each run of elements, it yields the key and the elements of the
+// run in order.
+//
+// The same key may appear more than once if its elements are not adjacent.
+// Use [GroupBy] to gather all elements sharing a key.
+//
+// ChunkBy holds only the current run in memory. Each yielded slice is freshly
+// allocated, so it may be retained by the loop body.
+func ChunkBy[T any, K comparable](iterator iter.Seq[T], key func(T) K) iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		var run []T
+		var current K
+		for v := range iterator {
+			k := key(v)
+			if len(run) > 0 && k != current {
+				if !yield(current, run) {
+					return
+				}
+				run = nil
+			}
+			current = k
+			run = append(run, v)
+		}
+		if len(run) > 0 {
+			yield(current, run)
+		}
+	}
+}
+
+// GroupBy collects the elements of the sequence into a map keyed by key.
+// The elements of each group are in the order of the sequence.
+//
+// Unlike [ChunkBy], GroupBy consumes the whole sequence before returning.
+func GroupBy[T any, K comparable](iterator iter.Seq[T], key func(T) K) map[K][]T {
+	m := make(map[K][]T)
+	for v := range iterator {
+		k := key(v)
+		m[k] = append(m[k], v)
+	}
+	return m
+}
@@ -0,0 +1,78 @@
+package transform_test
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/bufioscanner"
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleChunkBy() {
+	log := "req1 start\nreq1 done\nreq2 start\nreq1 retry\n"
+	s := bufioscanner.NewScanner(strings.NewReader(log))
+	requestID := func(line string) string { return strings.Fields(line)[0] }
+	for id, lines := range transform.ChunkBy(transform.Values(s.Text()), requestID) {
+		fmt.Println(id, len(lines))
+	}
+	// Output:
+	// req1 2
+	// req2 1
+	// req1 1
+}
+
+func TestChunkBy(t *testing.T) {
+	type run struct {
+		key  bool
+		vals []int
+	}
+	odd := func(v int) bool { return v%2 == 1 }
+	tests := []struct {
+		name string
+		in   []int
+		want []run
+	}{
+		{"empty", []int{}, nil},
+		{"single run", []int{1, 3, 5}, []run{{true, []int{1, 3, 5}}}},
+		{"runs", []int{1, 3, 2, 4, 5}, []run{{true, []int{1, 3}}, {false, []int{2, 4}}, {true, []int{5}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []run
+			for k, vals := range transform.ChunkBy(slices.Values(tt.in), odd) {
+				got = append(got, run{k, vals})
+			}
+			if !slices.EqualFunc(got, tt.want, func(a, b run) bool {
+				return a.key == b.key && slices.Equal(a.vals, b.vals)
+			}) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("break", func(t *testing.T) {
+		n := 0
+		for range transform.ChunkBy(slices.Values([]int{1, 2, 3}), odd) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("n must be 1, but %d", n)
+		}
+	})
+}
+
+func TestGroupBy(t *testing.T) {
+	m := transform.GroupBy(slices.Values([]string{"apple", "bean", "avocado", "banana", "cherry"}), func(s string) byte { return s[0] })
+	want := map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"bean", "banana"},
+		'c': {"cherry"},
+	}
+	if !maps.EqualFunc(m, want, slices.Equal) {
+		t.Errorf("got %v", m)
+	}
+}
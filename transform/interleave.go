@@ -0,0 +1,60 @@
+package transform
+
+import "iter"
+
+// Interleave returns an iterator that yields one element of each iterator in
+// turn, in the order of the arguments, and then starts over.
+// Interleave stops as soon as the iterator whose turn it is has no more
+// elements, so the last round may be partial.
+// Use [RoundRobin] to continue with the remaining iterators instead.
+func Interleave[T any](iterators ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if len(iterators) == 0 {
+			return
+		}
+		nexts := make([]func() (T, bool), len(iterators))
+		for i, iterator := range iterators {
+			next, stop := iter.Pull(iterator)
+			defer stop()
+			nexts[i] = next
+		}
+		for {
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok || !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// RoundRobin returns an iterator that yields one element of each iterator in
+// turn, in the order of the arguments, and then starts over.
+// An iterator that has no more elements is removed from the rotation, and
+// RoundRobin continues with the remaining ones until all are exhausted.
+// Use [Interleave] to stop at the first exhausted iterator instead.
+func RoundRobin[T any](iterators ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nexts := make([]func() (T, bool), 0, len(iterators))
+		for _, iterator := range iterators {
+			next, stop := iter.Pull(iterator)
+			defer stop()
+			nexts = append(nexts, next)
+		}
+		for len(nexts) > 0 {
+			active := nexts[:0]
+			for _, next := range nexts {
+				v, ok := next()
+				if !ok {
+					continue
+				}
+				active = append(active, next)
+				if !yield(v) {
+					return
+				}
+			}
+			nexts = active
+		}
+	}
+}
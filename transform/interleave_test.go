@@ -0,0 +1,73 @@
+package transform_test
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func TestInterleave(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][]int
+		want []int
+	}{
+		{"no iterators", nil, []int{}},
+		{"same length", [][]int{{1, 4}, {2, 5}, {3, 6}}, []int{1, 2, 3, 4, 5, 6}},
+		{"shorter last", [][]int{{1, 3, 5}, {2}}, []int{1, 2, 3}},
+		{"shorter first", [][]int{{1}, {2, 4}}, []int{1, 2}},
+		{"empty", [][]int{{1, 2}, {}}, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iterators []iter.Seq[int]
+			for _, s := range tt.in {
+				iterators = append(iterators, slices.Values(s))
+			}
+			got := slices.Collect(transform.Interleave(iterators...))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][]int
+		want []int
+	}{
+		{"no iterators", nil, []int{}},
+		{"same length", [][]int{{1, 4}, {2, 5}, {3, 6}}, []int{1, 2, 3, 4, 5, 6}},
+		{"different length", [][]int{{1, 4, 6, 7}, {2}, {3, 5}}, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"empty", [][]int{{}, {1, 2}, {}}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iterators []iter.Seq[int]
+			for _, s := range tt.in {
+				iterators = append(iterators, slices.Values(s))
+			}
+			got := slices.Collect(transform.RoundRobin(iterators...))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("break", func(t *testing.T) {
+		var got []int
+		for v := range transform.RoundRobin(slices.Values([]int{1, 3}), slices.Values([]int{2, 4})) {
+			got = append(got, v)
+			if v == 3 {
+				break
+			}
+		}
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+package transform
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// MergeSorted returns an iterator merging sequences that are each sorted in
+// ascending order into a single sorted sequence. It holds only the head
+// element of each sequence in memory, so it can merge sequences that do not
+// fit in memory, such as the runs of an external merge sort.
+//
+// Equal elements are yielded in the order of the arguments.
+// If an input is not sorted, the output is not sorted either.
+func MergeSorted[T cmp.Ordered](iterators ...iter.Seq[T]) iter.Seq[T] {
+	return MergeSortedFunc(cmp.Compare[T], iterators...)
+}
+
+// MergeSortedFunc is like [MergeSorted], but uses a comparison function.
+// cmp(a, b) should return a negative number when a < b, a positive number
+// when a > b and zero when a == b, as [slices.SortFunc] expects.
+// Each input must be sorted according to cmp.
+func MergeSortedFunc[T any](cmp func(T, T) int, iterators ...iter.Seq[T]) iter.Seq[T] {
+	iterators2 := make([]iter.Seq2[T, struct{}], len(iterators))
+	for i, iterator := range iterators {
+		iterators2[i] = MapOut(iterator, func(v T) (T, struct{}) { return v, struct{}{} })
+	}
+	return Keys(mergeSorted(cmp, iterators2))
+}
+
+// MergeSorted2 returns an iterator merging sequences of pairs that are each
+// sorted by key in ascending order into a single sequence sorted by key.
+//
+// Pairs with equal keys are yielded in the order of the arguments.
+func MergeSorted2[K cmp.Ordered, V any](iterators ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return mergeSorted(cmp.Compare[K], iterators)
+}
+
+// MergeSortedFunc2 is like [MergeSorted2], but uses a comparison function for
+// the keys. Each input must be sorted by key according to cmp.
+func MergeSortedFunc2[K, V any](cmp func(K, K) int, iterators ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return mergeSorted(cmp, iterators)
+}
+
+func mergeSorted[K, V any](cmp func(K, K) int, iterators []iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		h := &cursorHeap[K, V]{cmp: cmp}
+		for i, iterator := range iterators {
+			next, stop := iter.Pull2(iterator)
+			defer stop()
+			if k, v, ok := next(); ok {
+				h.cursors = append(h.cursors, &cursor[K, V]{k: k, v: v, next: next, index: i})
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			c := h.cursors[0]
+			if !yield(c.k, c.v) {
+				return
+			}
+			var ok bool
+			if c.k, c.v, ok = c.next(); ok {
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// cursor is the head of one of the sequences being merged.
+type cursor[K, V any] struct {
+	k     K
+	v     V
+	next  func() (K, V, bool)
+	index int // the position of the sequence in the arguments
+}
+
+// cursorHeap implements [heap.Interface] ordering cursors by key, then by index.
+type cursorHeap[K, V any] struct {
+	cursors []*cursor[K, V]
+	cmp     func(K, K) int
+}
+
+func (h *cursorHeap[K, V]) Len() int { return len(h.cursors) }
+
+func (h *cursorHeap[K, V]) Less(i, j int) bool {
+	a, b := h.cursors[i], h.cursors[j]
+	if c := h.cmp(a.k, b.k); c != 0 {
+		return c < 0
+	}
+	return a.index < b.index
+}
+
+func (h *cursorHeap[K, V]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+
+func (h *cursorHeap[K, V]) Push(x any) { h.cursors = append(h.cursors, x.(*cursor[K, V])) }
+
+func (h *cursorHeap[K, V]) Pop() any {
+	n := len(h.cursors) - 1
+	c := h.cursors[n]
+	h.cursors[n] = nil
+	h.cursors = h.cursors[:n]
+	return c
+}
+
+// MergeConcurrent returns an iterator that ranges each of the sequences on its
+// own goroutine and yields their elements as they arrive. The elements of each
+// sequence keep their order, but the sequences are interleaved arbitrarily.
+// This lets a single loop consume blocking sources, such as signals.Wait,
+// ticker.After and a work queue, without a hand-written select statement.
+//
+// The iterator ends when all the sequences have ended, when the loop body
+// breaks, or when ctx is done. A panic in one of the sequences is re-raised
+// in the goroutine ranging the result.
+//
+// As with [Prefetch], MergeConcurrent waits for the goroutines to exit before
+// it returns, and each goroutine stops ranging its sequence the next time the
+// sequence yields or returns. So a sequence that may block should observe
+// ctx, as signals.Wait and ticker.After do when given ctx.
+func MergeConcurrent[T any](ctx context.Context, iterators ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		// cases[0] is ctx.Done(); cases[i] receives from the sequence stopped
+		// by stops[i].
+		cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}}
+		stops := []func(){nil}
+		defer func() { stopAll(stops[1:]) }()
+		for _, iterator := range iterators {
+			ch, stop := pump(ctx, iterator, 0)
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)})
+			stops = append(stops, stop)
+		}
+		for len(cases) > 1 {
+			chosen, v, ok := reflect.Select(cases)
+			if chosen == 0 {
+				return
+			}
+			if !ok {
+				stop := stops[chosen]
+				cases = slices.Delete(cases, chosen, chosen+1)
+				stops = slices.Delete(stops, chosen, chosen+1)
+				stop() // re-raises a panic of the sequence
+				continue
+			}
+			var t T // v may hold a nil interface
+			reflect.ValueOf(&t).Elem().Set(v)
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// stopAll calls all the stop functions returned by [pump], even if some of
+// them panic, and then re-raises the first panic.
+func stopAll(stops []func()) {
+	var panicked any
+	for _, stop := range stops {
+		func() {
+			defer func() {
+				if p := recover(); p != nil && panicked == nil {
+					panicked = p
+				}
+			}()
+			stop()
+		}()
+	}
+	if panicked != nil {
+		panic(panicked)
+	}
+}
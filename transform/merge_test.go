@@ -0,0 +1,255 @@
+package transform_test
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/bufioreader"
+	"github.com/goaux/iter/chans"
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleMergeSorted() {
+	shard := func(s string) iter.Seq[string] {
+		return transform.Values(bufioreader.NewReader(strings.NewReader(s)).ReadString('\n'))
+	}
+	merged := transform.MergeSorted(
+		shard("09:00 a\n09:05 c\n"),
+		shard("09:01 b\n09:07 d\n"),
+	)
+	for line := range merged {
+		fmt.Print(line)
+	}
+	// Output:
+	// 09:00 a
+	// 09:01 b
+	// 09:05 c
+	// 09:07 d
+}
+
+func TestMergeSorted(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][]int
+		want []int
+	}{
+		{"no iterators", nil, []int{}},
+		{"empty iterators", [][]int{{}, {}}, []int{}},
+		{"single", [][]int{{1, 2, 3}}, []int{1, 2, 3}},
+		{"interleaved", [][]int{{1, 4, 7}, {2, 5, 8}, {3, 6, 9}}, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{"duplicates", [][]int{{1, 1, 3}, {1, 2}, {}}, []int{1, 1, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iterators []iter.Seq[int]
+			for _, s := range tt.in {
+				iterators = append(iterators, slices.Values(s))
+			}
+			got := slices.Collect(transform.MergeSorted(iterators...))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("break", func(t *testing.T) {
+		var got []int
+		for v := range transform.MergeSorted(slices.Values([]int{1, 3}), slices.Values([]int{2, 4})) {
+			got = append(got, v)
+			if v == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestMergeSortedFunc(t *testing.T) {
+	got := slices.Collect(transform.MergeSortedFunc(
+		func(a, b string) int { return cmp.Compare(len(a), len(b)) },
+		slices.Values([]string{"a", "ccc"}),
+		slices.Values([]string{"b", "dd"}),
+	))
+	if !slices.Equal(got, []string{"a", "b", "dd", "ccc"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestMergeSorted2(t *testing.T) {
+	var keys []int
+	var values []string
+	for k, v := range transform.MergeSorted2(
+		transform.Zip(slices.Values([]int{1, 3}), slices.Values([]string{"a", "c"})),
+		transform.Zip(slices.Values([]int{1, 2}), slices.Values([]string{"A", "B"})),
+	) {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !slices.Equal(keys, []int{1, 1, 2, 3}) {
+		t.Errorf("keys %v", keys)
+	}
+	if !slices.Equal(values, []string{"a", "A", "B", "c"}) {
+		t.Errorf("values %v", values)
+	}
+}
+
+func TestMergeSortedFunc2(t *testing.T) {
+	var values []int
+	for _, v := range transform.MergeSortedFunc2(
+		func(a, b int) int { return cmp.Compare(b, a) },
+		transform.Zip(slices.Values([]int{3, 1}), slices.Values([]int{30, 10})),
+		transform.Zip(slices.Values([]int{2}), slices.Values([]int{20})),
+	) {
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{30, 20, 10}) {
+		t.Errorf("got %v", values)
+	}
+}
+
+func ExampleMergeConcurrent() {
+	signals := make(chan string)
+	jobs := make(chan string)
+	handled := make(chan struct{})
+	go func() {
+		// Each value is sent after the previous one has been handled, so that
+		// the output is deterministic.
+		signals <- "signal: reload"
+		<-handled
+		jobs <- "job: 1"
+		<-handled
+		signals <- "signal: stop"
+		<-handled
+		close(signals)
+		close(jobs)
+	}()
+	ctx := context.TODO()
+	for v := range transform.MergeConcurrent(ctx, chans.FromChanCtx(ctx, signals), chans.FromChanCtx(ctx, jobs)) {
+		fmt.Println(v)
+		handled <- struct{}{}
+	}
+	// Output:
+	// signal: reload
+	// job: 1
+	// signal: stop
+}
+
+func TestMergeConcurrent(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		got := slices.Sorted(transform.MergeConcurrent(
+			context.TODO(),
+			slices.Values([]int{1, 4}),
+			slices.Values([]int{}),
+			slices.Values([]int{2, 3, 5}),
+		))
+		if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("order within a sequence", func(t *testing.T) {
+		var a, b []int
+		for v := range transform.MergeConcurrent(
+			context.TODO(),
+			slices.Values([]int{1, 2, 3, 4}),
+			slices.Values([]int{-1, -2, -3, -4}),
+		) {
+			if v > 0 {
+				a = append(a, v)
+			} else {
+				b = append(b, v)
+			}
+		}
+		if !slices.Equal(a, []int{1, 2, 3, 4}) || !slices.Equal(b, []int{-1, -2, -3, -4}) {
+			t.Errorf("got %v, %v", a, b)
+		}
+	})
+
+	t.Run("no iterators", func(t *testing.T) {
+		if got := slices.Collect(transform.MergeConcurrent[int](context.TODO())); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("cancel context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.TODO(), 50*time.Millisecond)
+		defer cancel()
+		blocking := func(yield func(int) bool) {
+			<-ctx.Done()
+		}
+		got := slices.Collect(transform.MergeConcurrent(ctx, slices.Values([]int{1}), blocking))
+		if !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("must panic with boom, but %v", r)
+			}
+		}()
+		source := func(yield func(int) bool) { panic("boom") }
+		for range transform.MergeConcurrent(context.TODO(), source) {
+		}
+	})
+
+	t.Run("panic of an endless merge", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("must panic with boom, but %v", r)
+			}
+		}()
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		endless := func(yield func(int) bool) {
+			for yield(1) {
+				time.Sleep(time.Millisecond)
+			}
+		}
+		source := func(yield func(int) bool) { panic("boom") }
+		for range transform.MergeConcurrent(ctx, endless, source) {
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var running atomic.Int32
+		source := func(yield func(int) bool) {
+			running.Add(1)
+			defer running.Add(-1)
+			for i := 0; yield(i); i++ {
+			}
+		}
+		for range transform.MergeConcurrent(context.TODO(), source, source) {
+			break
+		}
+		if n := running.Load(); n != 0 {
+			t.Errorf("running must be 0, but %d", n)
+		}
+	})
+
+	t.Run("panic after break", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("must panic with boom, but %v", r)
+			}
+		}()
+		source := func(yield func(int) bool) {
+			for i := 0; yield(i); i++ {
+			}
+			panic("boom")
+		}
+		for range transform.MergeConcurrent(context.TODO(), source) {
+			break
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package transform
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// ParallelMap transforms [iter.Seq][S] to [iter.Seq2][T, error] by calling f
+// on up to workers elements concurrently. The results are yielded in the
+// order of the sequence, so a slow element holds back the results after it;
+// use [ParallelMapUnordered] when the order does not matter.
+//
+// The first error returned by f is yielded and ends the sequence. If ctx is
+// done before the sequence ends, [context.Cause] of ctx is yielded as the
+// error. When the sequence ends or the loop body breaks, the context passed to
+// the pending calls of f is cancelled, and ParallelMap waits for them to
+// return. A panic in f or in the source sequence is re-raised in the goroutine
+// ranging the result.
+//
+// The source sequence is ranged on its own goroutine. It is stopped at its
+// next element when the result ends; a source that may block should observe
+// ctx, since ParallelMap waits for it.
+//
+// If workers is less than 1, it is considered to be 1.
+func ParallelMap[S, T any](ctx context.Context, iterator iter.Seq[S], workers int, f func(context.Context, S) (T, error)) iter.Seq2[T, error] {
+	workers = max(workers, 1)
+	return func(yield func(T, error) bool) {
+		parent := ctx
+		ctx, cancel := context.WithCancel(ctx)
+		in, stop := pump(ctx, iterator, 0)
+		defer stop()
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+		// Each element gets a future, queued in order; sem bounds the calls of f
+		// in progress.
+		futures := make(chan chan outcome[T], workers)
+		sem := make(chan struct{}, workers)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(futures)
+			for s := range in {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				future := make(chan outcome[T], 1)
+				select {
+				case futures <- future:
+				case <-ctx.Done():
+					<-sem
+					return
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer func() { <-sem }()
+					future <- try(ctx, f, s)
+				}()
+			}
+		}()
+		for future := range futures {
+			if !yieldOutcome(yield, <-future) {
+				return
+			}
+		}
+		yieldCause(parent, yield)
+	}
+}
+
+// ParallelMapUnordered is like [ParallelMap], but yields the results as soon
+// as they are available, regardless of the order of the sequence.
+func ParallelMapUnordered[S, T any](ctx context.Context, iterator iter.Seq[S], workers int, f func(context.Context, S) (T, error)) iter.Seq2[T, error] {
+	workers = max(workers, 1)
+	return func(yield func(T, error) bool) {
+		parent := ctx
+		ctx, cancel := context.WithCancel(ctx)
+		in, stop := pump(ctx, iterator, 0)
+		defer stop()
+		out := make(chan outcome[T])
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for s := range in {
+					select {
+					case out <- try(ctx, f, s):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		defer func() {
+			cancel()
+			for range out {
+				// drain until every worker has returned
+			}
+		}()
+		for o := range out {
+			if !yieldOutcome(yield, o) {
+				return
+			}
+		}
+		yieldCause(parent, yield)
+	}
+}
+
+// yieldOutcome yields the outcome of a call of f, and reports whether the
+// sequence should continue. It re-raises a panic of f.
+func yieldOutcome[T any](yield func(T, error) bool, o outcome[T]) bool {
+	if o.panicked != nil {
+		panic(o.panicked)
+	}
+	if o.err != nil {
+		var zero T
+		yield(zero, o.err)
+		return false
+	}
+	return yield(o.v, nil)
+}
+
+// yieldCause yields the cause of ctx as an error if ctx is done.
+func yieldCause[T any](ctx context.Context, yield func(T, error) bool) {
+	if ctx.Err() != nil {
+		var zero T
+		yield(zero, context.Cause(ctx))
+	}
+}
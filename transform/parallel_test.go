@@ -0,0 +1,221 @@
+package transform_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/bufioscanner"
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleParallelMap() {
+	s := bufioscanner.NewScanner(strings.NewReader("3\n1\n2\n"))
+	square := func(ctx context.Context, line string) (int, error) {
+		n, err := strconv.Atoi(line)
+		time.Sleep(time.Duration(n) * 10 * time.Millisecond)
+		return n * n, err
+	}
+	for v, err := range transform.ParallelMap(context.TODO(), transform.Values(s.Text()), 3, square) {
+		if err != nil {
+			fmt.Println("error:", err)
+			break
+		}
+		fmt.Println(v)
+	}
+	// Output:
+	// 9
+	// 1
+	// 4
+}
+
+func TestParallelMap(t *testing.T) {
+	in := make([]int, 50)
+	for i := range in {
+		in[i] = i
+	}
+	sleepy := func(ctx context.Context, v int) (int, error) {
+		time.Sleep(time.Duration(v%5) * time.Millisecond)
+		return v * 2, nil
+	}
+
+	t.Run("ordered", func(t *testing.T) {
+		var got []int
+		for v, err := range transform.ParallelMap(context.TODO(), slices.Values(in), 4, sleepy) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, v)
+		}
+		want := slices.Collect(transform.Map(slices.Values(in), func(v int) int { return v * 2 }))
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		var running, peak atomic.Int32
+		f := func(ctx context.Context, v int) (int, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return v, nil
+		}
+		for _, err := range transform.ParallelMap(context.TODO(), slices.Values(in), 3, f) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if p := peak.Load(); p > 3 {
+			t.Errorf("peak must not exceed 3, but %d", p)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errTest := errors.New("test")
+		f := func(ctx context.Context, v int) (int, error) {
+			if v == 3 {
+				return 0, errTest
+			}
+			return v, nil
+		}
+		var got []int
+		var gotErr error
+		for v, err := range transform.ParallelMap(context.TODO(), slices.Values(in), 4, f) {
+			if err != nil {
+				gotErr = err
+				continue
+			}
+			got = append(got, v)
+		}
+		if !slices.Equal(got, []int{0, 1, 2}) {
+			t.Errorf("got %v", got)
+		}
+		if gotErr != errTest {
+			t.Errorf("err must be errTest, but %v", gotErr)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var running atomic.Int32
+		f := func(ctx context.Context, v int) (int, error) {
+			running.Add(1)
+			defer running.Add(-1)
+			if v > 0 {
+				<-ctx.Done()
+			}
+			return v, ctx.Err()
+		}
+		endless := func(yield func(int) bool) {
+			for i := 0; yield(i); i++ {
+			}
+		}
+		for range transform.ParallelMap(context.TODO(), endless, 4, f) {
+			break
+		}
+		if n := running.Load(); n != 0 {
+			t.Errorf("running must be 0, but %d", n)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		cause := errors.New("cause")
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		cancel(cause)
+		var gotErr error
+		for _, err := range transform.ParallelMap(ctx, slices.Values(in), 4, sleepy) {
+			gotErr = err
+		}
+		if gotErr != cause {
+			t.Errorf("err must be cause, but %v", gotErr)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("must panic with boom, but %v", r)
+			}
+		}()
+		f := func(ctx context.Context, v int) (int, error) { panic("boom") }
+		for range transform.ParallelMap(context.TODO(), slices.Values(in), 4, f) {
+		}
+	})
+
+	t.Run("source panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("must panic with boom, but %v", r)
+			}
+		}()
+		source := func(yield func(int) bool) { panic("boom") }
+		for range transform.ParallelMap(context.TODO(), source, 4, sleepy) {
+		}
+	})
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	in := make([]int, 50)
+	for i := range in {
+		in[i] = i
+	}
+
+	t.Run("all", func(t *testing.T) {
+		f := func(ctx context.Context, v int) (int, error) {
+			time.Sleep(time.Duration(v%5) * time.Millisecond)
+			return v, nil
+		}
+		var got []int
+		for v, err := range transform.ParallelMapUnordered(context.TODO(), slices.Values(in), 4, f) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, v)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, in) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var running atomic.Int32
+		f := func(ctx context.Context, v int) (int, error) {
+			running.Add(1)
+			defer running.Add(-1)
+			return v, nil
+		}
+		for range transform.ParallelMapUnordered(context.TODO(), slices.Values(in), 4, f) {
+			break
+		}
+		if n := running.Load(); n != 0 {
+			t.Errorf("running must be 0, but %d", n)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errTest := errors.New("test")
+		f := func(ctx context.Context, v int) (int, error) { return 0, errTest }
+		n := 0
+		var gotErr error
+		for _, err := range transform.ParallelMapUnordered(context.TODO(), slices.Values(in), 4, f) {
+			n++
+			gotErr = err
+		}
+		if n != 1 || gotErr != errTest {
+			t.Errorf("got %d, %v", n, gotErr)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package transform
+
+import "iter"
+
+// Partition splits the sequence into two iterators: matched yields the
+// elements for which f returns true, and unmatched yields the others, both in
+// the order of the sequence. Unlike [Select], both sides are kept.
+//
+// The source sequence is ranged at most once, on demand, and f is called once
+// per element. When one of the two iterators is ahead of the other, the
+// elements the other has not consumed yet are buffered; once an iterator
+// stops ranging, nothing more is buffered for it. The source is stopped when
+// both iterators have stopped ranging or the source is exhausted. Until then,
+// a source that has been pulled from stays suspended on a goroutine of its
+// own, as with [iter.Pull]; so when breaking out of one iterator, release the
+// other even if it is not needed, for example with:
+//
+//	for range unmatched {
+//		break
+//	}
+//
+// Each returned iterator can be ranged only once. They may be ranged
+// alternately from a single goroutine, or concurrently from two goroutines.
+// Use [PartitionSlices] to collect both sides at once.
+func Partition[T any](iterator iter.Seq[T], f func(T) bool) (matched, unmatched iter.Seq[T]) {
+	s := newSplitter(iterator, func(v T) (T, bool, T, bool) {
+		ok := f(v)
+		return v, ok, v, !ok
+	})
+	return s.Left(), s.Right()
+}
+
+// PartitionSlices collects the elements of the sequence for which f returns
+// true into matched, and the others into unmatched, in the order of the
+// sequence.
+func PartitionSlices[T any](iterator iter.Seq[T], f func(T) bool) (matched, unmatched []T) {
+	for v := range iterator {
+		if f(v) {
+			matched = append(matched, v)
+		} else {
+			unmatched = append(unmatched, v)
+		}
+	}
+	return matched, unmatched
+}
@@ -0,0 +1,94 @@
+package transform_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func TestPartition(t *testing.T) {
+	valid := func(s string) bool {
+		_, err := strconv.Atoi(s)
+		return err == nil
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		calls := 0
+		f := func(s string) bool {
+			calls++
+			return valid(s)
+		}
+		matched, unmatched := transform.Partition(slices.Values([]string{"1", "x", "2", "y", "3"}), f)
+		if got := slices.Collect(unmatched); !slices.Equal(got, []string{"x", "y"}) {
+			t.Errorf("got %v", got)
+		}
+		if got := slices.Collect(matched); !slices.Equal(got, []string{"1", "2", "3"}) {
+			t.Errorf("got %v", got)
+		}
+		if calls != 5 {
+			t.Errorf("calls must be 5, but %d", calls)
+		}
+	})
+
+	t.Run("alternately", func(t *testing.T) {
+		matched, unmatched := transform.Partition(slices.Values([]string{"1", "x", "2", "y", "3"}), valid)
+		var got []string
+		for pair := range transform.ZipSliceAll(matched, unmatched) {
+			got = append(got, pair...)
+		}
+		if !slices.Equal(got, []string{"1", "x", "2", "y", "3", ""}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("break one side, then collect the other", func(t *testing.T) {
+		even, odd := transform.Partition(slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}), func(v int) bool { return v%2 == 0 })
+		for range even {
+			break
+		}
+		if got := slices.Collect(odd); !slices.Equal(got, []int{1, 3, 5, 7, 9}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("one side only", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(string) bool) {
+			defer func() { stopped = true }()
+			for i := 0; yield(strconv.Itoa(i)) && yield("x"); i++ {
+			}
+		}
+		matched, unmatched := transform.Partition(source, valid)
+		var got []string
+		for v := range matched {
+			got = append(got, v)
+			if len(got) == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []string{"0", "1"}) {
+			t.Errorf("got %v", got)
+		}
+		if stopped {
+			t.Error("source must not be stopped while unmatched may be ranged")
+		}
+		for range unmatched {
+			break
+		}
+		if !stopped {
+			t.Error("source must be stopped")
+		}
+	})
+}
+
+func TestPartitionSlices(t *testing.T) {
+	even, odd := transform.PartitionSlices(slices.Values([]int{1, 2, 3, 4, 5}), func(v int) bool { return v%2 == 0 })
+	if !slices.Equal(even, []int{2, 4}) {
+		t.Errorf("even %v", even)
+	}
+	if !slices.Equal(odd, []int{1, 3, 5}) {
+		t.Errorf("odd %v", odd)
+	}
+}
@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"iter"
+	"slices"
+)
+
+// Peekable is a pull iterator over a sequence with lookahead: it can look at
+// the elements ahead without consuming them, and push consumed elements back.
+// It suits hand-written parsers on top of a sequence, such as one joining the
+// continuation lines of multi-line log records.
+//
+// A Peekable must be stopped with [Peekable.Stop] when it is no longer
+// needed, unless the sequence has been consumed to the end.
+// A Peekable must not be used concurrently.
+type Peekable[T any] struct {
+	iterator iter.Seq[T]
+	next     func() (T, bool)
+	stop     func()
+	buf      []T // the elements peeked or unread, in order
+	done     bool
+}
+
+// NewPeekable creates a new [Peekable] over the sequence.
+// The sequence is not ranged until the first element is needed.
+func NewPeekable[T any](iterator iter.Seq[T]) *Peekable[T] {
+	return &Peekable[T]{iterator: iterator}
+}
+
+// Next returns the next element and true, or the zero value and false if the
+// sequence has ended.
+func (p *Peekable[T]) Next() (T, bool) {
+	var zero T
+	if !p.fill(1) {
+		return zero, false
+	}
+	v := p.buf[0]
+	p.buf[0] = zero
+	p.buf = p.buf[1:]
+	return v, true
+}
+
+// Peek returns the next element and true without consuming it, or the zero
+// value and false if the sequence has ended.
+func (p *Peekable[T]) Peek() (T, bool) {
+	if !p.fill(1) {
+		var zero T
+		return zero, false
+	}
+	return p.buf[0], true
+}
+
+// PeekN returns the next n elements without consuming them. The result is
+// shorter than n if the sequence ends before. The returned slice is a copy
+// and may be retained.
+func (p *Peekable[T]) PeekN(n int) []T {
+	p.fill(n)
+	return slices.Clone(p.buf[:min(max(n, 0), len(p.buf))])
+}
+
+// Unread pushes v back, so that it is the next element returned by
+// [Peekable.Next]. It may be called any number of times; the element pushed
+// last is returned first. v need not be an element of the sequence.
+func (p *Peekable[T]) Unread(v T) {
+	p.buf = slices.Insert(p.buf, 0, v)
+}
+
+// Stop stops the sequence. The elements already peeked or unread remain
+// available; after them, [Peekable.Next] reports that the sequence has ended.
+func (p *Peekable[T]) Stop() {
+	p.done = true
+	if p.stop != nil {
+		p.stop()
+	}
+}
+
+// Seq returns an iterator over the remaining elements. Breaking out of the
+// loop leaves the elements after the last one yielded available, so a parser
+// can range over a part of the sequence and then continue with
+// [Peekable.Next], or range again.
+func (p *Peekable[T]) Seq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			v, ok := p.Next()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// fill pulls elements until the buffer holds at least n of them, and reports
+// whether it does.
+func (p *Peekable[T]) fill(n int) bool {
+	for len(p.buf) < n && !p.done {
+		if p.next == nil {
+			p.next, p.stop = iter.Pull(p.iterator)
+		}
+		v, ok := p.next()
+		if !ok {
+			p.done = true
+			break
+		}
+		p.buf = append(p.buf, v)
+	}
+	return len(p.buf) >= n
+}
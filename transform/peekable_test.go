@@ -0,0 +1,123 @@
+package transform_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/bufioscanner"
+	"github.com/goaux/iter/transform"
+)
+
+func ExamplePeekable() {
+	log := "start\nerror: boom\n  at main.go:10\n  at main.go:20\ndone\n"
+	s := bufioscanner.NewScanner(strings.NewReader(log))
+	lines := transform.NewPeekable(transform.Values(s.Text()))
+	defer lines.Stop()
+	for line := range lines.Seq() {
+		// Join the indented continuation lines to the record.
+		for {
+			next, ok := lines.Peek()
+			if !ok || !strings.HasPrefix(next, " ") {
+				break
+			}
+			lines.Next()
+			line += " |" + next
+		}
+		fmt.Println(line)
+	}
+	// Output:
+	// start
+	// error: boom |  at main.go:10 |  at main.go:20
+	// done
+}
+
+func TestPeekable(t *testing.T) {
+	t.Run("next and peek", func(t *testing.T) {
+		p := transform.NewPeekable(slices.Values([]int{1, 2, 3}))
+		defer p.Stop()
+		if v, ok := p.Peek(); v != 1 || !ok {
+			t.Errorf("Peek must be 1, but %v, %v", v, ok)
+		}
+		if v, ok := p.Peek(); v != 1 || !ok {
+			t.Errorf("Peek must not consume, but %v, %v", v, ok)
+		}
+		if v, ok := p.Next(); v != 1 || !ok {
+			t.Errorf("Next must be 1, but %v, %v", v, ok)
+		}
+		if got := p.PeekN(5); !slices.Equal(got, []int{2, 3}) {
+			t.Errorf("PeekN must be [2 3], but %v", got)
+		}
+		if got := p.PeekN(1); !slices.Equal(got, []int{2}) {
+			t.Errorf("PeekN must be [2], but %v", got)
+		}
+		if got := slices.Collect(p.Seq()); !slices.Equal(got, []int{2, 3}) {
+			t.Errorf("got %v", got)
+		}
+		if v, ok := p.Next(); v != 0 || ok {
+			t.Errorf("Next must be 0, false, but %v, %v", v, ok)
+		}
+		if v, ok := p.Peek(); v != 0 || ok {
+			t.Errorf("Peek must be 0, false, but %v, %v", v, ok)
+		}
+	})
+
+	t.Run("unread", func(t *testing.T) {
+		p := transform.NewPeekable(slices.Values([]int{1, 2}))
+		defer p.Stop()
+		v, _ := p.Next()
+		p.Unread(v)
+		p.Unread(0)
+		if got := slices.Collect(p.Seq()); !slices.Equal(got, []int{0, 1, 2}) {
+			t.Errorf("got %v", got)
+		}
+		p.Unread(9)
+		if got := slices.Collect(p.Seq()); !slices.Equal(got, []int{9}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("break out of Seq", func(t *testing.T) {
+		p := transform.NewPeekable(slices.Values([]int{1, 2, 3, 4}))
+		defer p.Stop()
+		for v := range p.Seq() {
+			if v == 2 {
+				break
+			}
+		}
+		if got := slices.Collect(p.Seq()); !slices.Equal(got, []int{3, 4}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int) bool) {
+			defer func() { stopped = true }()
+			for i := 0; yield(i); i++ {
+			}
+		}
+		p := transform.NewPeekable(source)
+		p.PeekN(2)
+		p.Stop()
+		if !stopped {
+			t.Error("source must be stopped")
+		}
+		if got := slices.Collect(p.Seq()); !slices.Equal(got, []int{0, 1}) {
+			t.Errorf("peeked elements must remain, but %v", got)
+		}
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		ranged := false
+		p := transform.NewPeekable(func(yield func(int) bool) { ranged = true })
+		p.Stop()
+		if ranged {
+			t.Error("source must not be ranged")
+		}
+		if got := p.PeekN(-1); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
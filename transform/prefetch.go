@@ -0,0 +1,35 @@
+package transform
+
+import (
+	"context"
+	"iter"
+)
+
+// Prefetch returns an iterator that ranges the sequence on its own goroutine
+// and keeps up to n elements queued ahead of the loop body, so that a slow
+// source, such as a [bufio.Reader] on a network connection, overlaps with a
+// slow loop body. A negative n is considered to be 0, in which case at most
+// one element is pulled ahead.
+//
+// The goroutine stops ranging the source when the loop body breaks, when the
+// sequence ends, or when ctx is done, in which case the queued elements are
+// discarded. In every case Prefetch waits for the goroutine to exit before
+// returning; a source that may block should observe ctx. A panic in the source
+// is re-raised in the goroutine ranging the result, after the elements queued
+// before it.
+func Prefetch[T any](ctx context.Context, iterator iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ch, stop := pump(ctx, iterator, n)
+		defer stop()
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || ctx.Err() != nil || !yield(v) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package transform_test
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/iter/transform"
+)
+
+func TestPrefetch(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		got := slices.Collect(transform.Prefetch(context.TODO(), slices.Values([]int{1, 2, 3}), 2))
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("read ahead", func(t *testing.T) {
+		var pulled atomic.Int32
+		source := func(yield func(int) bool) {
+			for i := 0; i < 10; i++ {
+				pulled.Add(1)
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		for v := range transform.Prefetch(context.TODO(), source, 3) {
+			if v == 0 {
+				// 0 is being handled, 1, 2 and 3 are queued, 4 waits to be sent.
+				deadline := time.Now().Add(5 * time.Second)
+				for pulled.Load() < 5 && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				if n := pulled.Load(); n != 5 {
+					t.Errorf("pulled must be 5, but %d", n)
+				}
+			}
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		var running atomic.Bool
+		source := func(yield func(int) bool) {
+			running.Store(true)
+			defer running.Store(false)
+			for i := 0; yield(i); i++ {
+			}
+		}
+		for range transform.Prefetch(context.TODO(), source, 2) {
+			break
+		}
+		if running.Load() {
+			t.Error("the source must have returned")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+		n := 0
+		for range transform.Prefetch(ctx, slices.Values(make([]int, 100)), 2) {
+			if n++; n == 3 {
+				cancel()
+			}
+		}
+		if n != 3 {
+			t.Errorf("n must be 3, but %d", n)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		var got []int
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("must panic with boom, but %v", r)
+			}
+			if !slices.Equal(got, []int{1, 2}) {
+				t.Errorf("got %v", got)
+			}
+		}()
+		source := func(yield func(int) bool) {
+			_ = yield(1) && yield(2)
+			panic("boom")
+		}
+		for v := range transform.Prefetch(context.TODO(), source, 2) {
+			got = append(got, v)
+		}
+	})
+}
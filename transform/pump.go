@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"context"
+	"iter"
+)
+
+// pump ranges the sequence on a new goroutine and sends its elements to the
+// returned channel, which buffers up to n elements. The channel is closed when
+// the sequence ends, when it panics, or when ctx is done.
+//
+// The returned stop function must be called when the channel is no longer
+// received from. It makes the goroutine stop ranging the sequence, waits for
+// it to exit, and then re-raises a panic of the sequence in the calling
+// goroutine. The goroutine can only stop when the sequence yields or returns,
+// so a sequence that blocks should observe ctx.
+func pump[T any](ctx context.Context, iterator iter.Seq[T], n int) (<-chan T, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	ch := make(chan T, max(n, 0))
+	done := make(chan struct{})
+	var panicked any
+	go func() {
+		defer close(done)
+		defer close(ch)
+		defer func() { panicked = recover() }()
+		for v := range iterator {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	stop := func() {
+		cancel()
+		<-done
+		if panicked != nil {
+			panic(panicked)
+		}
+	}
+	return ch, stop
+}
+
+// outcome is the result of calling a function on another goroutine.
+type outcome[T any] struct {
+	v        T
+	err      error
+	panicked any
+}
+
+// try calls f and recovers a panic of f into the outcome.
+func try[S, T any](ctx context.Context, f func(context.Context, S) (T, error), s S) (o outcome[T]) {
+	defer func() { o.panicked = recover() }()
+	o.v, o.err = f(ctx, s)
+	return o
+}
@@ -0,0 +1,65 @@
+package transform
+
+import "iter"
+
+// Repeat returns an iterator that yields v forever.
+// It is typically bounded by [Take], or by a finite sequence it is zipped with.
+func Repeat[T any](v T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for yield(v) {
+		}
+	}
+}
+
+// RepeatN returns an iterator that yields v n times.
+// If n is less than 1, it yields nothing.
+func RepeatN[T any](v T, n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for range n {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Cycle returns an iterator that yields the elements of the sequence over and
+// over, forever. Each ranging ranges the source once, caching its elements in
+// memory, and then replays the cache; so the source may be a sequence that
+// can be ranged only once, as long as the result is ranged only once too.
+// If the sequence is empty, the iterator yields nothing.
+func Cycle[T any](iterator iter.Seq[T]) iter.Seq[T] {
+	return cycle(iterator, -1)
+}
+
+// CycleN is like [Cycle], but yields the elements of the sequence n times.
+// If n is less than 1, it yields nothing and does not range the source.
+func CycleN[T any](iterator iter.Seq[T], n int) iter.Seq[T] {
+	return cycle(iterator, max(n, 0))
+}
+
+// cycle yields the elements of the sequence n times, or forever if n is negative.
+func cycle[T any](iterator iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n == 0 {
+			return
+		}
+		var cache []T
+		for v := range iterator {
+			cache = append(cache, v)
+			if !yield(v) {
+				return
+			}
+		}
+		if len(cache) == 0 {
+			return
+		}
+		for i := 1; n < 0 || i < n; i++ {
+			for _, v := range cache {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
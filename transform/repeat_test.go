@@ -0,0 +1,129 @@
+package transform_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleCycle() {
+	jobs := slices.Values([]string{"job1", "job2", "job3", "job4", "job5"})
+	backends := transform.Cycle(slices.Values([]string{"a", "b"}))
+	for job, backend := range transform.Zip(jobs, backends) {
+		fmt.Println(job, backend)
+	}
+	// Output:
+	// job1 a
+	// job2 b
+	// job3 a
+	// job4 b
+	// job5 a
+}
+
+func TestRepeat(t *testing.T) {
+	got := slices.Collect(transform.Take(transform.Repeat("x"), 3))
+	if !slices.Equal(got, []string{"x", "x", "x"}) {
+		t.Errorf("got %v", got)
+	}
+	padded := slices.Collect(transform.Take(transform.Concat(slices.Values([]int{1, 2}), transform.Repeat(-1)), 4))
+	if !slices.Equal(padded, []int{1, 2, -1, -1}) {
+		t.Errorf("got %v", padded)
+	}
+}
+
+func TestRepeatN(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{-1, []int{}},
+		{0, []int{}},
+		{1, []int{7}},
+		{3, []int{7, 7, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			got := slices.AppendSeq([]int{}, transform.RepeatN(7, tt.n))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCycle(t *testing.T) {
+	t.Run("single-pass source", func(t *testing.T) {
+		ranged := 0
+		source := func(yield func(int) bool) {
+			ranged++
+			for _, v := range []int{1, 2, 3} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		got := slices.Collect(transform.Take(transform.Cycle(source), 7))
+		if !slices.Equal(got, []int{1, 2, 3, 1, 2, 3, 1}) {
+			t.Errorf("got %v", got)
+		}
+		if ranged != 1 {
+			t.Errorf("source must be ranged once, but %d", ranged)
+		}
+	})
+
+	t.Run("break during the first pass", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int) bool) {
+			defer func() { stopped = true }()
+			for _, v := range []int{1, 2, 3, 4, 5} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		var got []int
+		for _, v := range transform.Zip(slices.Values([]int{1, 2}), transform.Cycle(source)) {
+			got = append(got, v)
+		}
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("got %v", got)
+		}
+		if !stopped {
+			t.Error("source must be stopped")
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if got := slices.Collect(transform.Cycle(slices.Values([]int{}))); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestCycleN(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{-1, []int{}},
+		{0, []int{}},
+		{1, []int{1, 2}},
+		{3, []int{1, 2, 1, 2, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			got := slices.AppendSeq([]int{}, transform.CycleN(slices.Values([]int{1, 2}), tt.n))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if got := slices.Collect(transform.CycleN(slices.Values([]int{}), 3)); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package transform
+
+import (
+	"encoding/gob"
+	"io"
+	"iter"
+	"os"
+	"sync"
+)
+
+// Replay returns an iterator that can be ranged any number of times over a
+// sequence that can be ranged only once, such as the iterators of
+// bufioreader.Reader or signals.Wait.
+//
+// The source is ranged at most once, lazily: the elements are pulled from it
+// and cached in memory as the furthest ranging of the returned iterator
+// advances, and later rangings replay the cache before pulling more. If a
+// ranging stops early, the source stays suspended and the next ranging that
+// gets past the cache resumes it.
+//
+// The suspended source holds a goroutine, as with [iter.Pull], which is
+// released only when the source has been ranged to the end. A Replay that is
+// abandoned before that, such as over an endless source, keeps the goroutine
+// alive; use [FileReplay], whose Close stops the source, when that matters.
+//
+// The whole sequence is held in memory once it has been ranged to the end.
+// Use [FileReplay] to keep it in a temporary file instead.
+// The returned iterator may be ranged concurrently; a ranging reading cached
+// elements does not wait for another one that is pulling from the source.
+func Replay[T any](iterator iter.Seq[T]) iter.Seq[T] {
+	r := &replay[T]{iterator: iterator}
+	r.cond = sync.NewCond(&r.mu)
+	return func(yield func(T) bool) {
+		for i := 0; ; i++ {
+			v, ok := r.get(i)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+type replay[T any] struct {
+	mu       sync.Mutex
+	cond     *sync.Cond // signaled when pulling ends
+	iterator iter.Seq[T]
+	next     func() (T, bool)
+	stop     func()
+	cache    []T
+	pulling  bool // a ranging is pulling from the source without holding mu
+	done     bool
+}
+
+// get returns the i-th element of the sequence, pulling it if necessary.
+func (r *replay[T]) get(i int) (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for {
+		if i < len(r.cache) {
+			return r.cache[i], true
+		}
+		if r.done {
+			var zero T
+			return zero, false
+		}
+		if r.pulling {
+			r.cond.Wait()
+		} else {
+			r.pull()
+		}
+	}
+}
+
+// pull gets the next element from the source and caches it. r.mu must be
+// held; it is released while waiting for the source.
+func (r *replay[T]) pull() {
+	if r.next == nil {
+		r.next, r.stop = iter.Pull(r.iterator)
+	}
+	r.pulling = true
+	r.mu.Unlock()
+	var v T
+	ok := false
+	defer func() {
+		r.mu.Lock()
+		r.pulling = false
+		if ok {
+			r.cache = append(r.cache, v)
+		} else {
+			r.done = true // the source is exhausted, or it panicked
+			r.stop()
+		}
+		r.cond.Broadcast()
+	}()
+	v, ok = r.next()
+}
+
+// FileReplay is like [Replay], but caches the elements in a temporary file
+// instead of memory, so that it can replay sequences too large to hold in
+// memory. The elements are encoded with [encoding/gob], so T must be a type
+// gob can encode and decode.
+//
+// Errors are reported by [FileReplay.Err], and the temporary file is removed
+// by [FileReplay.Close].
+type FileReplay[T any] struct {
+	mu       sync.Mutex
+	cond     *sync.Cond // signaled when pulling ends
+	iterator iter.Seq[T]
+	dir      string
+	next     func() (T, bool)
+	stop     func()
+	file     *os.File
+	enc      *gob.Encoder
+	n        int  // the number of elements written to file
+	pulling  bool // a ranging is pulling from the source without holding mu
+	done     bool
+	err      error
+}
+
+// NewFileReplay creates a new [FileReplay] over the sequence.
+// The temporary file is created in dir when the first element is pulled;
+// if dir is the empty string, the default directory for temporary files is
+// used, as with [os.CreateTemp].
+func NewFileReplay[T any](iterator iter.Seq[T], dir string) *FileReplay[T] {
+	r := &FileReplay[T]{iterator: iterator, dir: dir}
+	r.cond = sync.NewCond(&r.mu)
+	return r
+}
+
+// All returns an iterator over the elements of the sequence.
+// It can be ranged any number of times, also concurrently.
+// The iterator stops early if an error occurs; see [FileReplay.Err].
+func (r *FileReplay[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var dec *gob.Decoder // reads the elements of this ranging from file
+		for i := 0; ; i++ {
+			v, ok := r.get(i, &dec)
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error that occurred while writing or reading the
+// temporary file.
+func (r *FileReplay[T]) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close stops the source if it has not been ranged to the end, and removes
+// the temporary file. After Close, [FileReplay.All] yields nothing.
+// If a ranging is waiting for the source, Close waits for it to get the
+// element first.
+func (r *FileReplay[T]) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for r.pulling {
+		r.cond.Wait()
+	}
+	r.finish(nil)
+	r.n = 0
+	if r.file == nil {
+		return nil
+	}
+	file := r.file
+	r.file = nil
+	err := file.Close()
+	if rerr := os.Remove(file.Name()); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// get returns the i-th element of the sequence, pulling and writing it to the
+// file first if necessary. Every ranging reads the elements back from the
+// file through its own decoder *dec, which get creates on first use. The
+// elements are decoded without holding r.mu.
+func (r *FileReplay[T]) get(i int, dec **gob.Decoder) (T, bool) {
+	var v T
+	r.mu.Lock()
+	for i >= r.n && !r.done {
+		if r.pulling {
+			r.cond.Wait()
+		} else {
+			r.pull()
+		}
+	}
+	if i >= r.n {
+		r.mu.Unlock()
+		return v, false
+	}
+	file := r.file
+	r.mu.Unlock()
+	if *dec == nil {
+		*dec = gob.NewDecoder(&fileReader{file: file})
+	}
+	if err := (*dec).Decode(&v); err != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.file == file { // otherwise, Close has closed the file meanwhile
+			r.finish(err)
+		}
+		return v, false
+	}
+	return v, true
+}
+
+// pull gets the next element from the source and writes it to the file.
+// r.mu must be held; it is released while waiting for the source.
+func (r *FileReplay[T]) pull() {
+	if r.next == nil {
+		r.next, r.stop = iter.Pull(r.iterator)
+	}
+	r.pulling = true
+	r.mu.Unlock()
+	var v T
+	ok := false
+	defer func() {
+		r.mu.Lock()
+		r.pulling = false
+		if !ok {
+			r.finish(nil) // the source is exhausted, or it panicked
+		} else if err := r.write(v); err != nil {
+			r.finish(err)
+		}
+		r.cond.Broadcast()
+	}()
+	v, ok = r.next()
+}
+
+// write appends v to the temporary file, creating it if necessary.
+// r.mu must be held.
+func (r *FileReplay[T]) write(v T) error {
+	if r.file == nil {
+		file, err := os.CreateTemp(r.dir, "replay-*")
+		if err != nil {
+			return err
+		}
+		r.file = file
+		r.enc = gob.NewEncoder(file)
+	}
+	if err := r.enc.Encode(&v); err != nil {
+		return err
+	}
+	r.n++
+	return nil
+}
+
+// finish stops the source and records err if it is the first error.
+// r.mu must be held.
+func (r *FileReplay[T]) finish(err error) {
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+	if !r.done {
+		r.done = true
+		if r.stop != nil {
+			r.stop()
+		}
+	}
+}
+
+// fileReader reads a file sequentially from its own offset, independently of
+// the offset of the file used for writing.
+//
+// It implements [io.ByteReader] so that [gob.Decoder] does not buffer it and
+// never reads past the element being decoded.
+type fileReader struct {
+	file *os.File
+	off  int64
+}
+
+func (r *fileReader) Read(p []byte) (int, error) {
+	n, err := r.file.ReadAt(p, r.off)
+	r.off += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (r *fileReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
@@ -0,0 +1,236 @@
+package transform_test
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goaux/iter/bufioreader"
+	"github.com/goaux/iter/transform"
+)
+
+func TestReplay(t *testing.T) {
+	t.Run("single pass source", func(t *testing.T) {
+		r := bufioreader.NewReader(strings.NewReader("a\nb\nc"))
+		lines := transform.Replay(transform.Values(r.ReadString('\n')))
+		want := []string{"a\n", "b\n", "c"}
+		for range 2 {
+			if got := slices.Collect(lines); !slices.Equal(got, want) {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		}
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		calls := 0
+		source := func(yield func(int) bool) {
+			calls++
+			for _, v := range []int{1, 2, 3, 4} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		seq := transform.Replay(source)
+		var got []int
+		for v := range seq {
+			got = append(got, v)
+			if v == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("got %v", got)
+		}
+		if got := slices.Collect(seq); !slices.Equal(got, []int{1, 2, 3, 4}) {
+			t.Errorf("got %v", got)
+		}
+		if calls != 1 {
+			t.Errorf("calls must be 1, but %d", calls)
+		}
+	})
+
+	t.Run("cached while pulling", func(t *testing.T) {
+		blocked := make(chan struct{})
+		release := make(chan struct{})
+		source := func(yield func(int) bool) {
+			if !yield(1) {
+				return
+			}
+			close(blocked)
+			<-release
+			yield(2)
+		}
+		seq := transform.Replay(source)
+		done := make(chan []int)
+		go func() { done <- slices.Collect(seq) }()
+		<-blocked
+		// The other ranging is waiting for the source; the cached element must
+		// still be available.
+		for v := range seq {
+			if v != 1 {
+				t.Errorf("got %d", v)
+			}
+			break
+		}
+		close(release)
+		if got := <-done; !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		seq := transform.Replay(slices.Values([]int{}))
+		if got := slices.Collect(seq); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+		if got := slices.Collect(seq); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestFileReplay(t *testing.T) {
+	type record struct {
+		ID   int
+		Name string
+	}
+
+	t.Run("replay", func(t *testing.T) {
+		dir := t.TempDir()
+		want := []record{{1, "a"}, {2, "b"}, {3, "c"}}
+		calls := 0
+		source := func(yield func(record) bool) {
+			calls++
+			for _, v := range want {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		r := transform.NewFileReplay(source, dir)
+		var got []record
+		for v := range r.All() {
+			got = append(got, v)
+			if v.ID == 2 {
+				break
+			}
+		}
+		if !slices.Equal(got, want[:2]) {
+			t.Errorf("got %v", got)
+		}
+		for range 2 {
+			if got := slices.Collect(r.All()); !slices.Equal(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("calls must be 1, but %d", calls)
+		}
+		if err := r.Err(); err != nil {
+			t.Error(err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Errorf("there must be a temporary file, but %v", entries)
+		}
+		if err := r.Close(); err != nil {
+			t.Error(err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("the temporary file must be removed, but %v", entries)
+		}
+		if got := slices.Collect(r.All()); len(got) != 0 {
+			t.Errorf("got %v after Close", got)
+		}
+	})
+
+	t.Run("interleaved", func(t *testing.T) {
+		r := transform.NewFileReplay(slices.Values([]int{0, 1, 2, 3}), t.TempDir())
+		defer r.Close()
+		var got [][2]int
+		for pair := range transform.ZipSlice(r.All(), r.All()) {
+			got = append(got, [2]int{pair[0], pair[1]})
+		}
+		if !slices.Equal(got, [][2]int{{0, 0}, {1, 1}, {2, 2}, {3, 3}}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("cached while pulling", func(t *testing.T) {
+		blocked := make(chan struct{})
+		release := make(chan struct{})
+		source := func(yield func(int) bool) {
+			if !yield(1) {
+				return
+			}
+			close(blocked)
+			<-release
+			yield(2)
+		}
+		r := transform.NewFileReplay(source, t.TempDir())
+		defer r.Close()
+		done := make(chan []int)
+		go func() { done <- slices.Collect(r.All()) }()
+		<-blocked
+		// The other ranging is waiting for the source; the cached element must
+		// still be available.
+		for v := range r.All() {
+			if v != 1 {
+				t.Errorf("got %d", v)
+			}
+			break
+		}
+		close(release)
+		if got := <-done; !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("got %v", got)
+		}
+		if err := r.Err(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		want := make([]int, 100)
+		for i := range want {
+			want[i] = i
+		}
+		r := transform.NewFileReplay(slices.Values(want), t.TempDir())
+		defer r.Close()
+		results := make(chan []int, 4)
+		for range 4 {
+			go func() { results <- slices.Collect(r.All()) }()
+		}
+		for range 4 {
+			if got := <-results; !slices.Equal(got, want) {
+				t.Errorf("got %v", got)
+			}
+		}
+		if err := r.Err(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		r := transform.NewFileReplay(slices.Values([]func(){func() {}}), t.TempDir())
+		defer r.Close()
+		if got := slices.Collect(r.All()); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+		if r.Err() == nil {
+			t.Error("Err must not be nil")
+		}
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		r := transform.NewFileReplay(slices.Values([]int{1}), "/nonexistent/dir")
+		defer r.Close()
+		if got := slices.Collect(r.All()); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+		if err := r.Err(); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Err must be ErrNotExist, but %v", err)
+		}
+	})
+}
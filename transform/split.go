@@ -0,0 +1,148 @@
+package transform
+
+import (
+	"iter"
+	"sync"
+)
+
+// splitter distributes the elements of a single-pass sequence between two
+// sequences, left and right. The source is pulled on demand by whichever side
+// needs the next element; elements routed to the other side are buffered
+// until that side consumes them. Once a side stops ranging, elements are no
+// longer buffered for it, and once both sides have stopped the source is
+// stopped. A side that is never ranged keeps the source suspended, so the
+// functions built on splitter document that both sides must be released.
+//
+// The two sides may be ranged from different goroutines.
+type splitter[T, L, R any] struct {
+	mu       sync.Mutex
+	iterator iter.Seq[T]
+	route    func(T) (L, bool, R, bool)
+	next     func() (T, bool)
+	stop     func()
+	left     []L
+	right    []R
+	leftOff  bool // the left side has stopped ranging
+	rightOff bool // the right side has stopped ranging
+	done     bool // the source has been exhausted or stopped
+}
+
+func newSplitter[T, L, R any](iterator iter.Seq[T], route func(T) (L, bool, R, bool)) *splitter[T, L, R] {
+	return &splitter[T, L, R]{iterator: iterator, route: route}
+}
+
+// pull gets the next element from the source and buffers its routed values.
+// It reports false if the source is exhausted. s.mu must be held.
+func (s *splitter[T, L, R]) pull() bool {
+	if s.done {
+		return false
+	}
+	if s.next == nil {
+		s.next, s.stop = iter.Pull(s.iterator)
+	}
+	v, ok := s.next()
+	if !ok {
+		s.done = true
+		s.stop()
+		return false
+	}
+	l, okL, r, okR := s.route(v)
+	if okL && !s.leftOff {
+		s.left = append(s.left, l)
+	}
+	if okR && !s.rightOff {
+		s.right = append(s.right, r)
+	}
+	return true
+}
+
+// off stops the source if both sides have stopped ranging.
+// s.mu must be held.
+func (s *splitter[T, L, R]) off() {
+	if s.leftOff && s.rightOff && !s.done {
+		s.done = true
+		if s.stop != nil {
+			s.stop()
+		}
+	}
+}
+
+func (s *splitter[T, L, R]) nextLeft() (L, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.leftOff {
+		if len(s.left) > 0 {
+			v := s.left[0]
+			var zero L
+			s.left[0] = zero
+			s.left = s.left[1:]
+			return v, true
+		}
+		if !s.pull() {
+			break
+		}
+	}
+	var zero L
+	return zero, false
+}
+
+func (s *splitter[T, L, R]) nextRight() (R, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.rightOff {
+		if len(s.right) > 0 {
+			v := s.right[0]
+			var zero R
+			s.right[0] = zero
+			s.right = s.right[1:]
+			return v, true
+		}
+		if !s.pull() {
+			break
+		}
+	}
+	var zero R
+	return zero, false
+}
+
+func (s *splitter[T, L, R]) stopLeft() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leftOff = true
+	s.left = nil
+	s.off()
+}
+
+func (s *splitter[T, L, R]) stopRight() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rightOff = true
+	s.right = nil
+	s.off()
+}
+
+// Left returns the left sequence. It can be ranged only once.
+func (s *splitter[T, L, R]) Left() iter.Seq[L] {
+	return func(yield func(L) bool) {
+		defer s.stopLeft()
+		for {
+			v, ok := s.nextLeft()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Right returns the right sequence. It can be ranged only once.
+func (s *splitter[T, L, R]) Right() iter.Seq[R] {
+	return func(yield func(R) bool) {
+		defer s.stopRight()
+		for {
+			v, ok := s.nextRight()
+			if !ok || !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package transform
+
+import "iter"
+
+// Take returns an iterator over the first n elements of the sequence.
+// If the sequence has fewer than n elements, all of them are yielded.
+// A negative n is considered to be 0.
+//
+// Unlike [Resize], Take never pads the sequence with zero values.
+func Take[T any](iterator iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range iterator {
+			if !yield(v) {
+				return
+			}
+			if i++; i >= n {
+				return
+			}
+		}
+	}
+}
+
+// Take2 returns an iterator over the first n pairs of the sequence.
+// If the sequence has fewer than n pairs, all of them are yielded.
+// A negative n is considered to be 0.
+//
+// Unlike [Resize2], Take2 never pads the sequence with zero values.
+func Take2[K, V any](iterator iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for k, v := range iterator {
+			if !yield(k, v) {
+				return
+			}
+			if i++; i >= n {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns an iterator over the leading elements of the sequence for
+// which f returns true. The sequence stops at the first element for which f
+// returns false; that element is not yielded.
+func TakeWhile[T any](iterator iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range iterator {
+			if !f(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile2 returns an iterator over the leading pairs of the sequence for
+// which f returns true. The sequence stops at the first pair for which f
+// returns false; that pair is not yielded.
+func TakeWhile2[K, V any](iterator iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range iterator {
+			if !f(k, v) || !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile returns an iterator that skips the leading elements of the
+// sequence for which f returns true, and then yields the first element for
+// which f returns false and all the elements after it.
+// Once an element has been yielded, f is not called anymore.
+func DropWhile[T any](iterator iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for v := range iterator {
+			if dropping {
+				if f(v) {
+					continue
+				}
+				dropping = false
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile2 returns an iterator that skips the leading pairs of the sequence
+// for which f returns true, and then yields the first pair for which f
+// returns false and all the pairs after it.
+// Once a pair has been yielded, f is not called anymore.
+func DropWhile2[K, V any](iterator iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		dropping := true
+		for k, v := range iterator {
+			if dropping {
+				if f(k, v) {
+					continue
+				}
+				dropping = false
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// SkipWhile is the same as [DropWhile].
+// It is provided for symmetry with [Skip].
+func SkipWhile[T any](iterator iter.Seq[T], f func(T) bool) iter.Seq[T] {
+	return DropWhile(iterator, f)
+}
+
+// SkipWhile2 is the same as [DropWhile2].
+// It is provided for symmetry with [Skip2].
+func SkipWhile2[K, V any](iterator iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return DropWhile2(iterator, f)
+}
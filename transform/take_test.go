@@ -0,0 +1,145 @@
+package transform_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/goaux/iter/transform"
+)
+
+func TestTake(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want []int
+	}{
+		{"n < 0", -1, []int{}},
+		{"n = 0", 0, []int{}},
+		{"0 < n < len", 2, []int{11, 22}},
+		{"n = len", 3, []int{11, 22, 33}},
+		{"n > len", 5, []int{11, 22, 33}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Collect(transform.Take(slices.Values([]int{11, 22, 33}), tt.n))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("got %v, want %v", s, tt.want)
+			}
+		})
+	}
+
+	t.Run("does not pull beyond n", func(t *testing.T) {
+		pulled := 0
+		seq := func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		s := slices.Collect(transform.Take(seq, 3))
+		if !slices.Equal(s, []int{0, 1, 2}) {
+			t.Errorf("got %v", s)
+		}
+		if pulled != 3 {
+			t.Errorf("pulled must be 3, but %d", pulled)
+		}
+	})
+}
+
+func TestTake2(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want map[int]int
+	}{
+		{"n = 0", 0, map[int]int{}},
+		{"0 < n < len", 2, map[int]int{0: 11, 1: 22}},
+		{"n > len", 5, map[int]int{0: 11, 1: 22, 2: 33}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := maps.Collect(transform.Take2(slices.All([]int{11, 22, 33}), tt.n))
+			if !maps.Equal(m, tt.want) {
+				t.Errorf("got %v, want %v", m, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeWhile(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"empty", []int{}, []int{}},
+		{"prefix", []int{1, 2, 5, 1}, []int{1, 2}},
+		{"all", []int{1, 2, 3}, []int{1, 2, 3}},
+		{"none", []int{5, 1}, []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Collect(transform.TakeWhile(slices.Values(tt.in), func(v int) bool { return v < 4 }))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("got %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeWhile2(t *testing.T) {
+	m := maps.Collect(transform.TakeWhile2(
+		slices.All([]string{"a", "b", "", "c"}),
+		func(_ int, s string) bool { return s != "" },
+	))
+	want := map[int]string{0: "a", 1: "b"}
+	if !maps.Equal(m, want) {
+		t.Errorf("got %v, want %v", m, want)
+	}
+}
+
+func TestDropWhile(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"empty", []int{}, []int{}},
+		{"prefix", []int{1, 2, 5, 1}, []int{5, 1}},
+		{"all", []int{1, 2, 3}, []int{}},
+		{"none", []int{5, 1}, []int{5, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := slices.Collect(transform.DropWhile(slices.Values(tt.in), func(v int) bool { return v < 4 }))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("got %v, want %v", s, tt.want)
+			}
+			s = slices.Collect(transform.SkipWhile(slices.Values(tt.in), func(v int) bool { return v < 4 }))
+			if !slices.Equal(s, tt.want) {
+				t.Errorf("SkipWhile: got %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestDropWhile2(t *testing.T) {
+	m := maps.Collect(transform.DropWhile2(
+		slices.All([]string{"", "", "a", "", "b"}),
+		func(_ int, s string) bool { return s == "" },
+	))
+	want := map[int]string{2: "a", 3: "", 4: "b"}
+	if !maps.Equal(m, want) {
+		t.Errorf("got %v, want %v", m, want)
+	}
+	m = maps.Collect(transform.SkipWhile2(
+		slices.All([]string{"", "", "a", "", "b"}),
+		func(_ int, s string) bool { return s == "" },
+	))
+	if !maps.Equal(m, want) {
+		t.Errorf("SkipWhile2: got %v, want %v", m, want)
+	}
+}
@@ -0,0 +1,164 @@
+package transform
+
+import (
+	"iter"
+	"sync"
+)
+
+// Tee returns n iterators that each yield all the elements of the sequence,
+// ranging the sequence only once. This lets a single-pass sequence, such as
+// one backed by a [bufio.Scanner], be consumed by several independent readers.
+//
+// The elements that have been pulled from the source but not yet consumed by
+// every returned iterator are buffered. The buffer grows without bound, so the
+// returned iterators may be ranged one after another from a single goroutine;
+// in that case the whole sequence is held in memory until the last iterator
+// starts. Use [TeeBuffer] to bound the buffer.
+//
+// Each returned iterator can be ranged only once. An iterator that stops
+// ranging early no longer holds elements in the buffer. The source is stopped
+// when all the returned iterators have stopped ranging.
+// The returned iterators may be ranged concurrently.
+//
+// If n is less than 1, Tee returns nil.
+func Tee[T any](iterator iter.Seq[T], n int) []iter.Seq[T] {
+	return TeeBuffer(iterator, n, 0)
+}
+
+// TeeBuffer is like [Tee], but buffers at most size elements.
+// When an iterator is size elements ahead of the slowest iterator still
+// ranging, it blocks until the slowest one catches up. Therefore the returned
+// iterators must be ranged concurrently from different goroutines, and an
+// iterator that is never ranged blocks the others once the buffer is full.
+//
+// If size is less than 1, the buffer grows without bound as with [Tee].
+func TeeBuffer[T any](iterator iter.Seq[T], n, size int) []iter.Seq[T] {
+	if n < 1 {
+		return nil
+	}
+	t := &tee[T]{
+		iterator: iterator,
+		size:     size,
+		pos:      make([]int, n),
+		active:   make([]bool, n),
+		nactive:  n,
+	}
+	t.cond = sync.NewCond(&t.mu)
+	iterators := make([]iter.Seq[T], n)
+	for i := range n {
+		t.active[i] = true
+		iterators[i] = func(yield func(T) bool) {
+			defer t.release(i)
+			for {
+				v, ok := t.get(i)
+				if !ok || !yield(v) {
+					return
+				}
+			}
+		}
+	}
+	return iterators
+}
+
+type tee[T any] struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	iterator iter.Seq[T]
+	next     func() (T, bool)
+	stop     func()
+	size     int    // the maximum length of buf, or unbounded if less than 1
+	buf      []T    // the elements not yet consumed by every active reader
+	base     int    // the index in the sequence of buf[0]
+	pos      []int  // the index in the sequence of the next element of each reader
+	active   []bool // whether each reader may still consume elements
+	nactive  int
+	pulling  bool // a reader is pulling the next element from the source
+	done     bool // the source has been exhausted or stopped
+}
+
+// get returns the next element for the reader i.
+func (t *tee[T]) get(i int) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.active[i] {
+		if p := t.pos[i] - t.base; p < len(t.buf) {
+			v := t.buf[p]
+			t.pos[i]++
+			t.trim()
+			return v, true
+		}
+		if t.done {
+			break
+		}
+		if t.pulling || (t.size > 0 && len(t.buf) >= t.size) {
+			t.cond.Wait()
+			continue
+		}
+		t.pull()
+	}
+	var zero T
+	return zero, false
+}
+
+// pull appends the next element of the source to the buffer.
+// t.mu must be held; it is released while the source produces the element,
+// so that the other readers can consume the buffer in the meantime.
+func (t *tee[T]) pull() {
+	if t.next == nil {
+		t.next, t.stop = iter.Pull(t.iterator)
+	}
+	t.pulling = true
+	t.mu.Unlock()
+	var v T
+	ok := false
+	defer func() {
+		t.mu.Lock()
+		t.pulling = false
+		if ok {
+			t.buf = append(t.buf, v)
+		} else {
+			t.done = true // the source is exhausted, or it panicked
+		}
+		t.cond.Broadcast()
+	}()
+	v, ok = t.next()
+}
+
+// trim drops the elements every active reader has consumed.
+// t.mu must be held.
+func (t *tee[T]) trim() {
+	lowest := -1
+	for i, p := range t.pos {
+		if t.active[i] && (lowest < 0 || p < lowest) {
+			lowest = p
+		}
+	}
+	if lowest < 0 {
+		lowest = t.base + len(t.buf)
+	}
+	if k := lowest - t.base; k > 0 {
+		clear(t.buf[:k])
+		t.buf = t.buf[k:]
+		t.base = lowest
+		t.cond.Broadcast()
+	}
+}
+
+// release marks the reader i as no longer consuming elements.
+func (t *tee[T]) release(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.active[i] {
+		return
+	}
+	t.active[i] = false
+	t.nactive--
+	t.trim()
+	if t.nactive == 0 && !t.done {
+		t.done = true
+		if t.stop != nil {
+			t.stop()
+		}
+	}
+	t.cond.Broadcast()
+}
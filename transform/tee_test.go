@@ -0,0 +1,115 @@
+package transform_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goaux/iter/bufioscanner"
+	"github.com/goaux/iter/transform"
+)
+
+func ExampleTee() {
+	s := bufioscanner.NewScanner(strings.NewReader("3\n1\n2\n"))
+	lines := transform.Tee(transform.Values(s.Text()), 2)
+	fmt.Println(len(slices.Collect(lines[0])))
+	fmt.Println(slices.Max(slices.Collect(lines[1])))
+	// Output:
+	// 3
+	// 3
+}
+
+func TestTee(t *testing.T) {
+	t.Run("sequential", func(t *testing.T) {
+		calls := 0
+		source := func(yield func(int) bool) {
+			calls++
+			for _, v := range []int{1, 2, 3} {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		iterators := transform.Tee(source, 3)
+		for i, iterator := range iterators {
+			if got := slices.Collect(iterator); !slices.Equal(got, []int{1, 2, 3}) {
+				t.Errorf("%d: got %v", i, got)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("calls must be 1, but %d", calls)
+		}
+	})
+
+	t.Run("ranged twice", func(t *testing.T) {
+		iterators := transform.Tee(slices.Values([]int{1, 2}), 1)
+		for range iterators[0] {
+		}
+		if got := slices.Collect(iterators[0]); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		iterators := transform.Tee(slices.Values([]int{1, 2, 3}), 2)
+		for range iterators[0] {
+			break
+		}
+		if got := slices.Collect(iterators[1]); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("stop source", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int) bool) {
+			defer func() { stopped = true }()
+			for i := 0; yield(i); i++ {
+			}
+		}
+		iterators := transform.Tee(source, 2)
+		for range iterators[0] {
+			break
+		}
+		if stopped {
+			t.Error("source must not be stopped yet")
+		}
+		for range iterators[1] {
+			break
+		}
+		if !stopped {
+			t.Error("source must be stopped")
+		}
+	})
+
+	t.Run("n < 1", func(t *testing.T) {
+		if iterators := transform.Tee(slices.Values([]int{1}), 0); iterators != nil {
+			t.Errorf("got %v", iterators)
+		}
+	})
+}
+
+func TestTeeBuffer(t *testing.T) {
+	want := make([]int, 100)
+	for i := range want {
+		want[i] = i
+	}
+	iterators := transform.TeeBuffer(slices.Values(want), 3, 2)
+	got := make([][]int, len(iterators))
+	var wg sync.WaitGroup
+	for i, iterator := range iterators {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i] = slices.Collect(iterator)
+		}()
+	}
+	wg.Wait()
+	for i := range got {
+		if !slices.Equal(got[i], want) {
+			t.Errorf("%d: got %v", i, got[i])
+		}
+	}
+}
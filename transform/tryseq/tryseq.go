@@ -0,0 +1,206 @@
+// Package tryseq provides functions transforming iterators of values that may
+// fail, [iter.Seq2][T, error].
+//
+// Such a sequence yields (v, nil) for each value v, and (zero, err) when an
+// error occurs. The functions in this package stop at the first non-nil error:
+// they yield it and do not pull anything more from the source.
+package tryseq
+
+import (
+	"context"
+	"iter"
+
+	"github.com/goaux/iter/bufioreader"
+)
+
+// Map transforms [iter.Seq2][S, error] to [iter.Seq2][T, error] using f, which
+// transforms S to T. An error from the sequence or from f is yielded and ends
+// the sequence.
+func Map[S, T any](iterator iter.Seq2[S, error], f func(S) (T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for s, err := range iterator {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			t, err := f(s)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Select returns an iterator over the values of the sequence for which f
+// returns true. An error from the sequence or from f is yielded and ends the
+// sequence.
+func Select[T any](iterator iter.Seq2[T, error], f func(T) (bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for v, err := range iterator {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			ok, err := f(v)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if ok && !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap transforms each value of [iter.Seq2][S, error] to an
+// [iter.Seq2][T, error] using f, and returns a single iterator concatenating
+// the results. An error from the sequence or from any result is yielded and
+// ends the sequence.
+func FlatMap[S, T any](iterator iter.Seq2[S, error], f func(S) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for s, err := range iterator {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for t, err := range f(s) {
+				if err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(t, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect collects the values of the sequence into a new slice.
+// It stops at the first error and returns the values collected so far along
+// with the error.
+func Collect[T any](iterator iter.Seq2[T, error]) ([]T, error) {
+	var s []T
+	for v, err := range iterator {
+		if err != nil {
+			return s, err
+		}
+		s = append(s, v)
+	}
+	return s, nil
+}
+
+// FirstError ranges the sequence until the first error and returns it.
+// It returns nil if the sequence ends without an error.
+func FirstError[T any](iterator iter.Seq2[T, error]) error {
+	for _, err := range iterator {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromErr converts an iterator that reports its error out-of-band into an
+// [iter.Seq2][V, error]. It yields the values of the sequence, dropping the
+// keys, and then yields the result of err if it is not nil.
+//
+// It fits the iterators of this module that return the loop index along with
+// the value, and report errors by an Err method:
+//
+//	s := bufioscanner.NewScanner(r)
+//	lines := tryseq.FromErr(s.Text(), s.Err)
+func FromErr[K, V any](iterator iter.Seq2[K, V], err func() error) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for _, v := range iterator {
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := err(); err != nil {
+			var zero V
+			yield(zero, err)
+		}
+	}
+}
+
+// WithContext transforms [iter.Seq][T] to [iter.Seq2][T, error], checking ctx
+// before ranging the sequence and before yielding each value. When ctx is
+// done, [context.Cause] of ctx is yielded as the error and ends the sequence.
+// Like transform.WithContext, it cannot interrupt a source that blocks while
+// producing a value. Use [MapCtx] and [SelectCtx] to go on transforming the
+// sequence with functions that receive ctx.
+func WithContext[T any](ctx context.Context, iterator iter.Seq[T]) iter.Seq2[T, error] {
+	return checkContext(ctx, func(yield func(T, error) bool) {
+		for v := range iterator {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	})
+}
+
+// MapCtx is like [Map], but f receives ctx, and ctx is checked before each
+// value of the sequence: when ctx is done, [context.Cause] of ctx is yielded
+// as the error and ends the sequence, without calling f.
+func MapCtx[S, T any](ctx context.Context, iterator iter.Seq2[S, error], f func(context.Context, S) (T, error)) iter.Seq2[T, error] {
+	return Map(checkContext(ctx, iterator), func(s S) (T, error) { return f(ctx, s) })
+}
+
+// SelectCtx is like [Select], but f receives ctx, and ctx is checked before
+// each value of the sequence: when ctx is done, [context.Cause] of ctx is
+// yielded as the error and ends the sequence, without calling f.
+func SelectCtx[T any](ctx context.Context, iterator iter.Seq2[T, error], f func(context.Context, T) (bool, error)) iter.Seq2[T, error] {
+	return Select(checkContext(ctx, iterator), func(v T) (bool, error) { return f(ctx, v) })
+}
+
+// checkContext checks ctx before ranging the sequence and before yielding
+// each of its values, and yields [context.Cause] of ctx as the error when ctx
+// is done.
+func checkContext[T any](ctx context.Context, iterator iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if ctx.Err() != nil {
+			yield(zero, context.Cause(ctx))
+			return
+		}
+		for v, err := range iterator {
+			if ctx.Err() != nil {
+				yield(zero, context.Cause(ctx))
+				return
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ReadBytes returns an iterator that yields byte slices delimited by the given
+// byte using [bufioreader.Reader.ReadBytes], followed by the error of
+// [bufioreader.Reader.Err] if it is not nil.
+func ReadBytes(r *bufioreader.Reader, delim byte) iter.Seq2[[]byte, error] {
+	return FromErr(r.ReadBytes(delim), r.Err)
+}
+
+// ReadSlice returns an iterator that yields byte slices delimited by the given
+// byte using [bufioreader.Reader.ReadSlice], followed by the error of
+// [bufioreader.Reader.Err] if it is not nil.
+func ReadSlice(r *bufioreader.Reader, delim byte) iter.Seq2[[]byte, error] {
+	return FromErr(r.ReadSlice(delim), r.Err)
+}
+
+// ReadString returns an iterator that yields strings delimited by the given
+// byte using [bufioreader.Reader.ReadString], followed by the error of
+// [bufioreader.Reader.Err] if it is not nil.
+func ReadString(r *bufioreader.Reader, delim byte) iter.Seq2[string, error] {
+	return FromErr(r.ReadString(delim), r.Err)
+}
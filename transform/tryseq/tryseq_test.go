@@ -0,0 +1,332 @@
+package tryseq_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goaux/iter/bufioreader"
+	"github.com/goaux/iter/bufioscanner"
+	"github.com/goaux/iter/transform/tryseq"
+)
+
+func Example() {
+	r := bufioreader.NewReader(strings.NewReader("1\n2\nx\n4\n"))
+	numbers := tryseq.Map(
+		tryseq.ReadString(r, '\n'),
+		func(s string) (int, error) { return strconv.Atoi(strings.TrimSpace(s)) },
+	)
+	for n, err := range numbers {
+		if err != nil {
+			fmt.Println("error:", err)
+			break
+		}
+		fmt.Println(n)
+	}
+	// Output:
+	// 1
+	// 2
+	// error: strconv.Atoi: parsing "x": invalid syntax
+}
+
+var errTest = errors.New("test")
+
+// values returns a sequence yielding vs, followed by err if it is not nil,
+// and then more values that must never be reached.
+func values[T any](err error, vs ...T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, v := range vs {
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err != nil {
+			var zero T
+			if !yield(zero, err) {
+				return
+			}
+			panic("must not continue after an error")
+		}
+	}
+}
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      iter.Seq2[string, error]
+		want    []int
+		wantErr error
+	}{
+		{"ok", values[string](nil, "1", "2"), []int{1, 2}, nil},
+		{"source error", values(errTest, "1"), []int{1}, errTest},
+		{"f error", values[string](nil, "1", "x", "3"), []int{1}, strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tryseq.Collect(tryseq.Map(tt.in, strconv.Atoi))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	positive := func(v int) (bool, error) {
+		if v == 0 {
+			return false, errTest
+		}
+		return v > 0, nil
+	}
+	tests := []struct {
+		name    string
+		in      iter.Seq2[int, error]
+		want    []int
+		wantErr error
+	}{
+		{"ok", values[int](nil, 1, -2, 3), []int{1, 3}, nil},
+		{"source error", values(io.ErrUnexpectedEOF, 1, -2), []int{1}, io.ErrUnexpectedEOF},
+		{"f error", values[int](nil, 1, 0, 3), []int{1}, errTest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tryseq.Collect(tryseq.Select(tt.in, positive))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      iter.Seq2[int, error]
+		want    []int
+		wantErr error
+	}{
+		{"ok", values[int](nil, 1, 2), []int{1, 2, 2}, nil},
+		{"source error", values(errTest, 1), []int{1}, errTest},
+		{"inner error", values[int](nil, 1, 0, 2), []int{1}, io.ErrUnexpectedEOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tryseq.Collect(tryseq.FlatMap(tt.in, func(n int) iter.Seq2[int, error] {
+				if n == 0 {
+					return values[int](io.ErrUnexpectedEOF)
+				}
+				return values[int](nil, slices.Repeat([]int{n}, n)...)
+			}))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFirstError(t *testing.T) {
+	if err := tryseq.FirstError(values[int](nil, 1, 2)); err != nil {
+		t.Errorf("err must be nil, but %v", err)
+	}
+	if err := tryseq.FirstError(values(errTest, 1, 2)); err != errTest {
+		t.Errorf("err must be errTest, but %v", err)
+	}
+}
+
+func TestFromErr(t *testing.T) {
+	s := bufioscanner.NewScanner(iotest.TimeoutReader(strings.NewReader("a\nb\n")))
+	got, err := tryseq.Collect(tryseq.FromErr(s.Text(), s.Err))
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("got %v", got)
+	}
+	if !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("err must be ErrTimeout, but %v", err)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	t.Run("all", func(t *testing.T) {
+		got, err := tryseq.Collect(tryseq.WithContext(context.TODO(), slices.Values([]int{1, 2, 3})))
+		if err != nil || !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v, %v", got, err)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		defer cancel(nil)
+		var got []int
+		var gotErr error
+		for v, err := range tryseq.WithContext(ctx, slices.Values([]int{1, 2, 3})) {
+			if err != nil {
+				gotErr = err
+				continue
+			}
+			got = append(got, v)
+			if v == 2 {
+				cancel(errTest)
+			}
+		}
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("got %v", got)
+		}
+		if gotErr != errTest {
+			t.Errorf("err must be errTest, but %v", gotErr)
+		}
+	})
+
+	t.Run("done before start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+		got, err := tryseq.Collect(tryseq.WithContext(ctx, slices.Values([]int{1})))
+		if len(got) != 0 || err != context.Canceled {
+			t.Errorf("got %v, %v", got, err)
+		}
+	})
+}
+
+func ExampleMapCtx() {
+	ctx, cancel := context.WithCancelCause(context.TODO())
+	defer cancel(nil)
+	square := func(ctx context.Context, v int) (int, error) {
+		if v == 3 {
+			cancel(errors.New("stopped at 3"))
+		}
+		return v * v, nil
+	}
+	for v, err := range tryseq.MapCtx(ctx, tryseq.WithContext(ctx, slices.Values([]int{1, 2, 3, 4})), square) {
+		if err != nil {
+			fmt.Println("error:", err)
+			break
+		}
+		fmt.Println(v)
+	}
+	// Output:
+	// 1
+	// 4
+	// 9
+	// error: stopped at 3
+}
+
+func TestMapCtx(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.TODO(), ctxKey{}, 10)
+	f := func(ctx context.Context, v int) (int, error) { return v * ctx.Value(ctxKey{}).(int), nil }
+
+	t.Run("all", func(t *testing.T) {
+		got, err := tryseq.Collect(tryseq.MapCtx(ctx, values(nil, 1, 2), f))
+		if err != nil || !slices.Equal(got, []int{10, 20}) {
+			t.Errorf("got %v, %v", got, err)
+		}
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		got, err := tryseq.Collect(tryseq.MapCtx(ctx, values(errTest, 1), f))
+		if err != errTest || !slices.Equal(got, []int{10}) {
+			t.Errorf("got %v, %v", got, err)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+		calls := 0
+		g := func(ctx context.Context, v int) (int, error) {
+			calls++
+			cancel(errTest)
+			return v, nil
+		}
+		got, err := tryseq.Collect(tryseq.MapCtx(ctx, values(nil, 1, 2, 3), g))
+		if err != errTest || !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v, %v", got, err)
+		}
+		if calls != 1 {
+			t.Errorf("f must not be called after ctx is done, but %d calls", calls)
+		}
+	})
+}
+
+func TestSelectCtx(t *testing.T) {
+	odd := func(ctx context.Context, v int) (bool, error) { return v%2 == 1, ctx.Err() }
+
+	t.Run("all", func(t *testing.T) {
+		got, err := tryseq.Collect(tryseq.SelectCtx(context.TODO(), values(nil, 1, 2, 3), odd))
+		if err != nil || !slices.Equal(got, []int{1, 3}) {
+			t.Errorf("got %v, %v", got, err)
+		}
+	})
+
+	t.Run("done before start", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.TODO())
+		cancel(errTest)
+		got, err := tryseq.Collect(tryseq.SelectCtx(ctx, values(nil, 1, 2, 3), odd))
+		if err != errTest || len(got) != 0 {
+			t.Errorf("got %v, %v", got, err)
+		}
+	})
+}
+
+func TestReadString(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		r := bufioreader.NewReader(strings.NewReader("a\nb"))
+		got, err := tryseq.Collect(tryseq.ReadString(r, '\n'))
+		if !slices.Equal(got, []string{"a\n", "b"}) {
+			t.Errorf("got %q", got)
+		}
+		if err != nil {
+			t.Errorf("err must be nil, but %v", err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		r := bufioreader.NewReader(io.MultiReader(strings.NewReader("a\nb"), iotest.ErrReader(errTest)))
+		got, err := tryseq.Collect(tryseq.ReadString(r, '\n'))
+		if !slices.Equal(got, []string{"a\n"}) {
+			t.Errorf("got %q", got)
+		}
+		if !errors.Is(err, errTest) {
+			t.Errorf("err must be errTest, but %v", err)
+		}
+		if s := bufioreader.GetErrorBufferString(err); s != "b" {
+			t.Errorf("remain must be b, but %q", s)
+		}
+	})
+}
+
+func TestReadBytes(t *testing.T) {
+	r := bufioreader.NewReader(strings.NewReader("a\nb"))
+	got, err := tryseq.Collect(tryseq.ReadBytes(r, '\n'))
+	if len(got) != 2 || string(got[0]) != "a\n" || string(got[1]) != "b" || err != nil {
+		t.Errorf("got %q, %v", got, err)
+	}
+}
+
+func TestReadSlice(t *testing.T) {
+	r := bufioreader.NewReader(strings.NewReader("a\nb"))
+	var got []string
+	for b, err := range tryseq.ReadSlice(r, '\n') {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(b))
+	}
+	if !slices.Equal(got, []string{"a\n", "b"}) {
+		t.Errorf("got %q", got)
+	}
+}
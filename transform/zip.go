@@ -136,3 +136,159 @@ func ZipAll[S, T any](lhs iter.Seq[S], rhs iter.Seq[T]) iter.Seq2[S, T] {
 		}
 	}
 }
+
+// ZipSlice returns a composite iterator iter.Seq[[]T] from the iterators iter.Seq[T].
+// Each yielded slice holds one element of each iterator, in the order of the arguments.
+// ZipSlice iterates over the smallest of the sequences.
+// If no iterators are passed, the sequence is empty.
+//
+// Each yielded slice is freshly allocated, so it may be retained by the loop body.
+func ZipSlice[T any](iterators ...iter.Seq[T]) iter.Seq[[]T] {
+	return zipSlice(iterators, false)
+}
+
+// ZipSliceAll returns a composite iterator iter.Seq[[]T] from the iterators iter.Seq[T].
+// Each yielded slice holds one element of each iterator, in the order of the arguments.
+// ZipSliceAll iterates over the greatest of the sequences.
+// Zero values are used for any missing elements in the sequences with fewer elements.
+// If no iterators are passed, the sequence is empty.
+//
+// Each yielded slice is freshly allocated, so it may be retained by the loop body.
+func ZipSliceAll[T any](iterators ...iter.Seq[T]) iter.Seq[[]T] {
+	return zipSlice(iterators, true)
+}
+
+func zipSlice[T any](iterators []iter.Seq[T], all bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if len(iterators) == 0 {
+			return
+		}
+		nexts := make([]func() (T, bool), len(iterators))
+		for i, iterator := range iterators {
+			next, stop := iter.Pull(iterator)
+			defer stop()
+			nexts[i] = next
+		}
+		done := make([]bool, len(iterators))
+		for {
+			values := make([]T, len(iterators))
+			found := false
+			for i, next := range nexts {
+				if done[i] {
+					continue
+				}
+				v, ok := next()
+				if !ok {
+					if !all {
+						return
+					}
+					done[i] = true
+					continue
+				}
+				values[i] = v
+				found = true
+			}
+			if !found || !yield(values) {
+				return
+			}
+		}
+	}
+}
+
+// Tuple3 holds one element of each of three sequences. See [Zip3].
+type Tuple3[S, T, U any] struct {
+	V1 S
+	V2 T
+	V3 U
+}
+
+// Zip3 returns a composite iterator iter.Seq[Tuple3[S, T, U]] from three iterators.
+// Zip3 iterates over the smallest of the three sequences.
+func Zip3[S, T, U any](s iter.Seq[S], t iter.Seq[T], u iter.Seq[U]) iter.Seq[Tuple3[S, T, U]] {
+	return func(yield func(Tuple3[S, T, U]) bool) {
+		nextS, stopS := iter.Pull(s)
+		defer stopS()
+		nextT, stopT := iter.Pull(t)
+		defer stopT()
+		nextU, stopU := iter.Pull(u)
+		defer stopU()
+		for {
+			vs, ok := nextS()
+			if !ok {
+				return
+			}
+			vt, ok := nextT()
+			if !ok {
+				return
+			}
+			vu, ok := nextU()
+			if !ok {
+				return
+			}
+			if !yield(Tuple3[S, T, U]{V1: vs, V2: vt, V3: vu}) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip splits an iterator iter.Seq2[S, T] into two iterators iter.Seq[S] and iter.Seq[T].
+// It is the inverse of [Zip].
+//
+// The source sequence is ranged at most once, on demand. When one of the two
+// sequences is ahead of the other, the elements the other has not consumed yet
+// are buffered; once a sequence stops ranging, nothing more is buffered for it.
+// The source is stopped when both sequences have stopped ranging or the
+// source is exhausted. Until then, a source that has been pulled from stays
+// suspended on a goroutine of its own, as with [iter.Pull]; so when breaking
+// out of one sequence, release the other even if it is not needed, for
+// example with:
+//
+//	for range strs {
+//		break
+//	}
+//
+// Each returned sequence can be ranged only once. They may be ranged
+// alternately from a single goroutine, or concurrently from two goroutines.
+func Unzip[S, T any](iterator iter.Seq2[S, T]) (iter.Seq[S], iter.Seq[T]) {
+	type pair struct {
+		s S
+		t T
+	}
+	s := newSplitter(
+		MapIn(iterator, func(s S, t T) pair { return pair{s, t} }),
+		func(p pair) (S, bool, T, bool) { return p.s, true, p.t, true },
+	)
+	return s.Left(), s.Right()
+}
+
+// Opt holds an optional value. OK reports whether Value is present.
+type Opt[T any] struct {
+	Value T
+	OK    bool
+}
+
+// ZipLongest returns a composite iterator iter.Seq2[Opt[S], Opt[T]] from two iterators iter.Seq[S] and iter.Seq[T].
+// ZipLongest iterates over the greater of the two sequences.
+// Unlike [ZipAll], a missing element is reported by an [Opt] whose OK is false,
+// so it can be distinguished from a zero value in the sequence.
+func ZipLongest[S, T any](lhs iter.Seq[S], rhs iter.Seq[T]) iter.Seq2[Opt[S], Opt[T]] {
+	return func(yield func(Opt[S], Opt[T]) bool) {
+		nextL, stopL := iter.Pull(lhs)
+		defer stopL()
+		nextR, stopR := iter.Pull(rhs)
+		defer stopR()
+		for {
+			var l Opt[S]
+			var r Opt[T]
+			l.Value, l.OK = nextL()
+			r.Value, r.OK = nextR()
+			if !l.OK && !r.OK {
+				return
+			}
+			if !yield(l, r) {
+				return
+			}
+		}
+	}
+}
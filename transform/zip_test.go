@@ -1,10 +1,15 @@
 package transform_test
 
 import (
+	"fmt"
+	"iter"
 	"maps"
 	"slices"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/goaux/iter/bufioscanner"
 	"github.com/goaux/iter/transform"
 )
 
@@ -296,3 +301,258 @@ func TestZipAll(t *testing.T) {
 		}
 	})
 }
+
+func TestZipSlice(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      [][]int
+		want    [][]int
+		wantAll [][]int
+	}{
+		{"no iterators", nil, nil, nil},
+		{"same length", [][]int{{1, 2}, {3, 4}, {5, 6}}, [][]int{{1, 3, 5}, {2, 4, 6}}, [][]int{{1, 3, 5}, {2, 4, 6}}},
+		{"different length", [][]int{{1, 2}, {3}, {5, 6, 7}}, [][]int{{1, 3, 5}}, [][]int{{1, 3, 5}, {2, 0, 6}, {0, 0, 7}}},
+		{"zero length", [][]int{{1}, {}}, nil, [][]int{{1, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iterators []iter.Seq[int]
+			for _, s := range tt.in {
+				iterators = append(iterators, slices.Values(s))
+			}
+			got := slices.Collect(transform.ZipSlice(iterators...))
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("ZipSlice: got %v, want %v", got, tt.want)
+			}
+			got = slices.Collect(transform.ZipSliceAll(iterators...))
+			if !slices.EqualFunc(got, tt.wantAll, slices.Equal) {
+				t.Errorf("ZipSliceAll: got %v, want %v", got, tt.wantAll)
+			}
+		})
+	}
+}
+
+func TestZip3(t *testing.T) {
+	got := slices.Collect(transform.Zip3(
+		slices.Values([]int{1, 2, 3}),
+		slices.Values([]string{"a", "b"}),
+		slices.Values([]bool{true, false, true}),
+	))
+	want := []transform.Tuple3[int, string, bool]{
+		{V1: 1, V2: "a", V3: true},
+		{V1: 2, V2: "b", V3: false},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	pairs := func() iter.Seq2[int, string] {
+		return transform.Zip(slices.Values([]int{1, 2, 3}), slices.Values([]string{"a", "b", "c"}))
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		ints, strs := transform.Unzip(pairs())
+		if got := slices.Collect(ints); !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v", got)
+		}
+		if got := slices.Collect(strs); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("single pass source", func(t *testing.T) {
+		calls := 0
+		source := func(yield func(int, string) bool) {
+			calls++
+			pairs()(yield)
+		}
+		ints, strs := transform.Unzip(source)
+		var gotInts []int
+		var gotStrs []string
+		for v := range ints {
+			gotInts = append(gotInts, v)
+			if v == 2 {
+				break
+			}
+		}
+		for v := range strs {
+			gotStrs = append(gotStrs, v)
+		}
+		if !slices.Equal(gotInts, []int{1, 2}) {
+			t.Errorf("got %v", gotInts)
+		}
+		if !slices.Equal(gotStrs, []string{"a", "b", "c"}) {
+			t.Errorf("got %v", gotStrs)
+		}
+		if calls != 1 {
+			t.Errorf("calls must be 1, but %d", calls)
+		}
+	})
+
+	t.Run("stop both", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int, string) bool) {
+			defer func() { stopped = true }()
+			for i := 0; ; i++ {
+				if !yield(i, "") {
+					return
+				}
+			}
+		}
+		ints, strs := transform.Unzip(source)
+		for range ints {
+			break
+		}
+		for range strs {
+			break
+		}
+		if !stopped {
+			t.Error("source must be stopped")
+		}
+	})
+
+	t.Run("release the other side", func(t *testing.T) {
+		stopped := false
+		source := func(yield func(int, string) bool) {
+			defer func() { stopped = true }()
+			for i := 0; yield(i, ""); i++ {
+			}
+		}
+		ints, strs := transform.Unzip(source)
+		for v := range ints {
+			if v == 2 {
+				break
+			}
+		}
+		if stopped {
+			t.Error("source must not be stopped while strs may be ranged")
+		}
+		for range strs {
+			break
+		}
+		if !stopped {
+			t.Error("source must be stopped")
+		}
+	})
+
+	t.Run("other side still ranging", func(t *testing.T) {
+		ints, strs := transform.Unzip(pairs())
+		var gotInts []int
+		var gotStrs []string
+		for v := range strs {
+			gotStrs = append(gotStrs, v)
+			if v == "a" {
+				for v := range ints {
+					gotInts = append(gotInts, v)
+					break
+				}
+			}
+		}
+		if !slices.Equal(gotInts, []int{1}) {
+			t.Errorf("got %v", gotInts)
+		}
+		if !slices.Equal(gotStrs, []string{"a", "b", "c"}) {
+			t.Errorf("got %v", gotStrs)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		ints, strs := transform.Unzip(pairs())
+		var gotInts []int
+		var gotStrs []string
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			gotInts = slices.Collect(ints)
+		}()
+		go func() {
+			defer wg.Done()
+			gotStrs = slices.Collect(strs)
+		}()
+		wg.Wait()
+		if !slices.Equal(gotInts, []int{1, 2, 3}) {
+			t.Errorf("got %v", gotInts)
+		}
+		if !slices.Equal(gotStrs, []string{"a", "b", "c"}) {
+			t.Errorf("got %v", gotStrs)
+		}
+	})
+}
+
+func ExampleZipLongest() {
+	lhs := bufioscanner.NewScanner(strings.NewReader("a\nb\nc\n"))
+	rhs := bufioscanner.NewScanner(strings.NewReader("a\nB\n"))
+	for l, r := range transform.ZipLongest(transform.Values(lhs.Text()), transform.Values(rhs.Text())) {
+		switch {
+		case !r.OK:
+			fmt.Printf("- %s\n", l.Value)
+		case !l.OK:
+			fmt.Printf("+ %s\n", r.Value)
+		case l.Value != r.Value:
+			fmt.Printf("- %s\n+ %s\n", l.Value, r.Value)
+		default:
+			fmt.Printf("  %s\n", l.Value)
+		}
+	}
+	// Output:
+	//   a
+	// - b
+	// + B
+	// - c
+}
+
+func TestZipLongest(t *testing.T) {
+	type pair struct {
+		l transform.Opt[int]
+		r transform.Opt[string]
+	}
+	collect := func(lhs []int, rhs []string) []pair {
+		var got []pair
+		for l, r := range transform.ZipLongest(slices.Values(lhs), slices.Values(rhs)) {
+			got = append(got, pair{l, r})
+		}
+		return got
+	}
+
+	t.Run("same length", func(t *testing.T) {
+		got := collect([]int{0, 1}, []string{"", "a"})
+		want := []pair{
+			{transform.Opt[int]{0, true}, transform.Opt[string]{"", true}},
+			{transform.Opt[int]{1, true}, transform.Opt[string]{"a", true}},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("len(lhs) < len(rhs)", func(t *testing.T) {
+		got := collect([]int{0}, []string{"a", "b"})
+		want := []pair{
+			{transform.Opt[int]{0, true}, transform.Opt[string]{"a", true}},
+			{transform.Opt[int]{}, transform.Opt[string]{"b", true}},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("len(lhs) > len(rhs)", func(t *testing.T) {
+		got := collect([]int{0, 1}, []string{""})
+		want := []pair{
+			{transform.Opt[int]{0, true}, transform.Opt[string]{"", true}},
+			{transform.Opt[int]{1, true}, transform.Opt[string]{}},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("zero length", func(t *testing.T) {
+		if got := collect(nil, nil); len(got) != 0 {
+			t.Errorf("got %v", got)
+		}
+	})
+}